@@ -0,0 +1,417 @@
+package wrengo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Returned by [Supervisor.Send] once the supervisor is stopped.
+var ErrSupervisorStopped = errors.New("wrengo: supervisor stopped")
+
+// Describes an [Actor].
+type ActorConfig struct {
+	// The name messages are sent to. Names are unique within a supervisor.
+	Name string
+
+	// The configuration the VM of the actor is created with. It may be shared
+	// by several actors.
+	Configuration Configuration
+
+	// Binds foreign classes and methods, and registers modules, on the VM of
+	// the actor, every time it is started.
+	Bind func(vm *VM) error
+
+	// Source interpreted in the main module of the VM, usually a loop receiving
+	// messages. The actor stops once it returns and no fiber waits anymore.
+	Source string
+
+	// How many messages the mailbox holds before senders wait.
+	//
+	// If zero, defaults to 64.
+	MailboxSize int
+
+	// How many times the actor is restarted after a runtime error before the
+	// supervisor gives up on it.
+	//
+	// If zero, defaults to 5. If negative, the actor is never restarted.
+	MaxRestarts int
+}
+
+// A VM running a script on its own goroutine, talking to other actors of its
+// [Supervisor] through messages only.
+type Actor struct {
+	supervisor *Supervisor
+	config     ActorConfig
+	mailbox    chan interface{}
+	done       chan struct{}
+
+	mu       sync.Mutex
+	restarts int
+	err      error
+}
+
+// Runs actors, routing their messages and restarting the ones whose script hit
+// a runtime error, with a fresh VM and the same mailbox.
+type Supervisor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// VMs are created one at a time, since actors may share a configuration.
+	factory sync.Mutex
+
+	mu        sync.Mutex
+	mailboxes map[string]chan interface{}
+}
+
+// The built-in "actor" module, available to the VMs of actors:
+//
+//	import "actor" for Actor
+//
+//	while (true) {
+//	  var message = Actor.receive()
+//	  Actor.send(message["replyTo"], {"from": Actor.name, "sum": message["a"] + message["b"]})
+//	}
+//
+// Receiving suspends the calling fiber until a message arrives, and sending
+// until the mailbox of the recipient has room, see [VM.RunLoop].
+//
+// Messages are copied, so they must be null, booleans, numbers, strings, lists
+// and maps of those. Sending to an unknown actor aborts the fiber.
+var actorModule = &Module{
+	Name: "actor",
+	Source: `
+import "scheduler" for Scheduler
+import "wrengo/value" for Value
+
+class Actor {
+  foreign static name
+
+  static send(name, message) {
+    if (!(name is String)) Fiber.abort("Name must be a string.")
+    send_(name, Value.encode(message), Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  static receive() {
+    receive_(Fiber.current)
+    return Value.decode(Scheduler.runNextScheduled_())
+  }
+
+  foreign static send_(name, message, fiber)
+  foreign static receive_(fiber)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Actor.name":         actorName,
+		"static Actor.send_(_,_,_)": actorSend,
+		"static Actor.receive_(_)":  actorReceive,
+	},
+}
+
+// Creates a supervisor without actors. It must be stopped with [Stop] when no
+// longer needed.
+func NewSupervisor() *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Supervisor{
+		ctx:       ctx,
+		cancel:    cancel,
+		mailboxes: make(map[string]chan interface{}),
+	}
+}
+
+// Starts an actor as described by [config].
+func (s *Supervisor) Start(config ActorConfig) (*Actor, error) {
+	if config.MailboxSize <= 0 {
+		config.MailboxSize = 64
+	}
+	if config.MaxRestarts == 0 {
+		config.MaxRestarts = 5
+	}
+
+	a := &Actor{
+		supervisor: s,
+		config:     config,
+		mailbox:    make(chan interface{}, config.MailboxSize),
+		done:       make(chan struct{}),
+	}
+	if err := s.Register(config.Name, a.mailbox); err != nil {
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go a.supervise()
+	return a, nil
+}
+
+// Routes the messages sent to [name] to [mailbox], so Go code can take part
+// in the conversation.
+//
+// Messages are converted as by [GetSlotValue], except that maps are supported
+// too and become map[interface{}]interface{}.
+func (s *Supervisor) Register(name string, mailbox chan interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.mailboxes[name]; ok {
+		return fmt.Errorf("wrengo: actor %q already exists", name)
+	}
+	s.mailboxes[name] = mailbox
+	return nil
+}
+
+// Stops routing the messages sent to [name].
+func (s *Supervisor) Unregister(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mailboxes, name)
+}
+
+func (s *Supervisor) mailbox(name string) (chan interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mailbox, ok := s.mailboxes[name]
+	return mailbox, ok
+}
+
+// Sends [message] to the actor called [name], waiting until its mailbox has
+// room or [ctx] is done.
+//
+// The message is converted as by [SetSlotValue], except that maps are
+// supported too. It is copied, so it may be modified once sent.
+func (s *Supervisor) Send(ctx context.Context, name string, message interface{}) error {
+	mailbox, ok := s.mailbox(name)
+	if !ok {
+		return fmt.Errorf("wrengo: no actor named %q", name)
+	}
+	message, err := copyMessage(message)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case mailbox <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return ErrSupervisorStopped
+	}
+}
+
+// Returns a copy of [message] sharing no memory with it, with numbers turned
+// into float64, lists into []interface{} and maps into
+// map[interface{}]interface{}.
+func copyMessage(message interface{}) (interface{}, error) {
+	return copyValue(message, make(map[visit]bool))
+}
+
+// A pointer, slice or map being copied by [copyValue].
+type visit struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+// Copies [value] like [copyMessage], refusing to follow the pointers, slices
+// and maps in [visiting] again.
+func copyValue(value interface{}, visiting map[visit]bool) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, string:
+		return v, nil
+	case []byte:
+		return append([]byte(nil), v...), nil
+	case Handle, *Handle:
+		return nil, errors.New("wrengo: cannot send a handle to another VM")
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() != reflect.Slice || rv.Len() > 0 {
+			v := visit{rv.Pointer(), rv.Type()}
+			if visiting[v] {
+				return nil, fmt.Errorf("wrengo: cannot send %T containing itself", value)
+			}
+			visiting[v] = true
+			defer delete(visiting, v)
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return copyValue(rv.Elem().Interface(), visiting)
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			element, err := copyValue(rv.Index(i).Interface(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = element
+		}
+		return list, nil
+	case reflect.Map:
+		m := make(map[interface{}]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := copyValue(iter.Key().Interface(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			if key != nil && !reflect.TypeOf(key).Comparable() {
+				return nil, fmt.Errorf("wrengo: cannot send a map with %s keys", iter.Key().Type())
+			}
+			value, err := copyValue(iter.Value().Interface(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("wrengo: cannot send %T to another VM", value)
+}
+
+// Stops every actor and waits until their VMs are freed.
+func (s *Supervisor) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Returns the name of the actor.
+func (a *Actor) Name() string {
+	return a.config.Name
+}
+
+// Returns a channel closed once the actor stopped, because its script
+// returned, it crashed too many times or the supervisor was stopped.
+func (a *Actor) Done() <-chan struct{} {
+	return a.done
+}
+
+// Returns the last runtime error of the actor, or nil if it never crashed.
+func (a *Actor) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// Returns how many times the actor was restarted.
+func (a *Actor) Restarts() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.restarts
+}
+
+// Runs the actor until it stops, restarting it after crashes.
+func (a *Actor) supervise() {
+	s := a.supervisor
+	defer s.wg.Done()
+	defer close(a.done)
+	defer s.Unregister(a.config.Name)
+
+	for {
+		err := a.run()
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+
+		a.mu.Lock()
+		a.err = err
+		restart := a.restarts < a.config.MaxRestarts
+		if restart {
+			a.restarts++
+		}
+		a.mu.Unlock()
+
+		if !restart {
+			return
+		}
+	}
+}
+
+// Runs the script of the actor in a new VM, until it is done or crashes.
+func (a *Actor) run() error {
+	s := a.supervisor
+
+	config := a.config.Configuration
+	config.actor = a
+	s.factory.Lock()
+	vm := NewVM(config)
+	s.factory.Unlock()
+	defer vm.FreeVM()
+
+	if a.config.Bind != nil {
+		if err := a.config.Bind(&vm); err != nil {
+			return err
+		}
+	}
+	if err := vm.Interpret(DefaultModule, a.config.Source); err != nil {
+		return err
+	}
+	return vm.RunLoop(s.ctx)
+}
+
+func actorName(vm *VM) {
+	vm.SetSlotString(0, vm.actor.config.Name)
+}
+
+func actorSend(vm *VM) {
+	var (
+		s    = vm.actor.supervisor
+		name = vm.GetSlotString(1)
+	)
+
+	mailbox, ok := s.mailbox(name)
+	if !ok {
+		vm.abortFiber(fmt.Sprintf("No actor named '%s'.", name))
+		return
+	}
+	message, err := vm.getSlotEncoded(2)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	vm.Async(3, func(ctx context.Context) (interface{}, error) {
+		select {
+		case mailbox <- message:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
+
+func actorReceive(vm *VM) {
+	mailbox := vm.actor.mailbox
+	vm.Async(1, func(ctx context.Context) (interface{}, error) {
+		select {
+		case message := <-mailbox:
+			return encodedValue{message}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
@@ -0,0 +1,79 @@
+package wrengo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActor(t *testing.T) {
+	s := NewSupervisor()
+	defer s.Stop()
+
+	out := make(chan interface{}, 10)
+	assert.NoError(t, s.Register("out", out))
+
+	a, err := s.Start(ActorConfig{
+		Name:          "echo",
+		Configuration: NewConfiguration(),
+		Source: `
+			import "actor" for Actor
+			while (true) {
+				var message = Actor.receive()
+				if (message["crash"]) Fiber.abort("Crashed.")
+				message["by"] = Actor.name
+				Actor.send("out", message)
+			}
+		`,
+	})
+	assert.NoError(t, err)
+	_, err = s.Start(ActorConfig{Name: "echo"})
+	assert.Error(t, err)
+
+	ctx := context.Background()
+	message := map[string]interface{}{"n": 1}
+	assert.NoError(t, s.Send(ctx, "echo", message))
+	message["n"] = 5
+	assert.Equal(t, map[interface{}]interface{}{"n": 1.0, "by": "echo"}, <-out)
+	assert.Len(t, message, 1)
+	assert.Error(t, s.Send(ctx, "echo", make(chan int)))
+	assert.Error(t, s.Send(ctx, "echo", map[[2]int]string{{1, 2}: "a"}))
+	cycle := []interface{}{nil}
+	cycle[0] = cycle
+	assert.Error(t, s.Send(ctx, "echo", cycle))
+
+	// The actor is restarted with its mailbox.
+	assert.NoError(t, s.Send(ctx, "echo", map[string]interface{}{"crash": true}))
+	assert.NoError(t, s.Send(ctx, "echo", map[string]interface{}{"crash": true}))
+	assert.NoError(t, s.Send(ctx, "echo", map[string]interface{}{"n": 2}))
+	assert.Equal(t, map[interface{}]interface{}{"n": 2.0, "by": "echo"}, <-out)
+	assert.Equal(t, 2, a.Restarts())
+	assert.Error(t, a.Err())
+
+	assert.Error(t, s.Send(ctx, "nobody", nil))
+
+	s.Stop()
+	<-a.Done()
+	assert.Error(t, s.Send(ctx, "echo", nil))
+}
+
+func TestActorGivesUp(t *testing.T) {
+	s := NewSupervisor()
+	defer s.Stop()
+
+	a, err := s.Start(ActorConfig{
+		Name:          "failing",
+		Configuration: NewConfiguration(),
+		Source: `
+			import "actor" for Actor
+			Actor.send("nobody", null)
+		`,
+		MaxRestarts: -1,
+	})
+	assert.NoError(t, err)
+
+	<-a.Done()
+	assert.Equal(t, 0, a.Restarts())
+	assert.Error(t, a.Err())
+}
@@ -0,0 +1,355 @@
+package wrengo
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The built-in "bignum" module, for exact arithmetic on numbers Wren's doubles
+// can't hold:
+//
+//	import "bignum" for BigInt, Decimal
+//
+//	var big = BigInt.new("9007199254740993") + 1
+//	var price = Decimal.new("0.1") + Decimal.new("0.2")
+//	System.print(price == Decimal.new("0.3"))
+//	System.print((Decimal.new(10) / 3).round(2))
+//
+// Operators accept BigInts, Decimals, numbers and strings. Operations on
+// BigInts that involve a fraction produce a Decimal. Division of BigInts
+// truncates and division of Decimals is exact, like a fraction.
+var bignumModule = &Module{
+	Name: "bignum",
+	Source: `
+foreign class BigInt {
+  construct new(value) { set_(value) }
+
+  foreign set_(value)
+
+  foreign +(other)
+  foreign -(other)
+  foreign *(other)
+  foreign /(other)
+  foreign %(other)
+  foreign -
+
+  foreign <(other)
+  foreign <=(other)
+  foreign >(other)
+  foreign >=(other)
+  foreign ==(other)
+  foreign !=(other)
+
+  foreign abs
+  foreign sign
+  foreign toNum
+  foreign toString
+}
+
+foreign class Decimal {
+  construct new(value) { set_(value) }
+
+  foreign set_(value)
+
+  foreign +(other)
+  foreign -(other)
+  foreign *(other)
+  foreign /(other)
+  foreign %(other)
+  foreign -
+
+  foreign <(other)
+  foreign <=(other)
+  foreign >(other)
+  foreign >=(other)
+  foreign ==(other)
+  foreign !=(other)
+
+  round(places) {
+    if (!(places is Num) || !places.isInteger || places < 0) {
+      Fiber.abort("Places must be a non-negative integer.")
+    }
+    return round_(places)
+  }
+
+  foreign abs
+  foreign sign
+  foreign toNum
+  foreign toString
+  foreign round_(places)
+}
+`,
+	Methods: bignumMethods(),
+	Classes: map[string]func(*VM) interface{}{
+		"BigInt":  func(*VM) interface{} { return new(big.Int) },
+		"Decimal": func(*VM) interface{} { return new(big.Rat) },
+	},
+}
+
+// Digits printed after the point of a Decimal that has no exact decimal
+// representation, like 1/3.
+const decimalPrecision = 20
+
+func bignumMethods() map[string]func(*VM) {
+	methods := make(map[string]func(*VM))
+	for _, class := range []string{"BigInt", "Decimal"} {
+		methods[class+".set_(_)"] = bignumSet
+		methods[class+".+(_)"] = bignumArithmetic(bignumAdd)
+		methods[class+".-(_)"] = bignumArithmetic(bignumSub)
+		methods[class+".*(_)"] = bignumArithmetic(bignumMul)
+		methods[class+"./(_)"] = bignumArithmetic(bignumDiv)
+		methods[class+".%(_)"] = bignumArithmetic(bignumMod)
+		methods[class+".-"] = bignumNeg
+		methods[class+".<(_)"] = bignumCompare(func(c int) bool { return c < 0 })
+		methods[class+".<=(_)"] = bignumCompare(func(c int) bool { return c <= 0 })
+		methods[class+".>(_)"] = bignumCompare(func(c int) bool { return c > 0 })
+		methods[class+".>=(_)"] = bignumCompare(func(c int) bool { return c >= 0 })
+		methods[class+".==(_)"] = bignumEqual(true)
+		methods[class+".!=(_)"] = bignumEqual(false)
+		methods[class+".abs"] = bignumAbs
+		methods[class+".sign"] = bignumSign
+		methods[class+".toNum"] = bignumToNum
+		methods[class+".toString"] = bignumToString
+	}
+	methods["Decimal.round_(_)"] = decimalRound
+	return methods
+}
+
+// Reads the number in [slot] as a *big.Int if it is an integer and [slot] is
+// not a Decimal, or a *big.Rat.
+func bignumOperand(vm *VM, slot int) (interface{}, error) {
+	switch vm.GetSlotType(slot) {
+	case WREN_TYPE_NUM:
+		f := vm.GetSlotDouble(slot)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, errors.New("Number must be finite.")
+		}
+		if f == math.Trunc(f) {
+			i, _ := big.NewFloat(f).Int(nil)
+			return i, nil
+		}
+		// The shortest representation, so 0.1 is one tenth.
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		return r, nil
+	case WREN_TYPE_STRING:
+		s := strings.TrimSpace(vm.GetSlotString(slot))
+		if i, ok := new(big.Int).SetString(s, 10); ok {
+			return i, nil
+		}
+		if r, ok := new(big.Rat).SetString(s); ok && !strings.ContainsAny(s, "/") {
+			return r, nil
+		}
+		return nil, errors.New("Invalid number.")
+	case WREN_TYPE_FOREIGN:
+		switch v := vm.GetSlotObject(slot).(type) {
+		case *big.Int:
+			return v, nil
+		case *big.Rat:
+			return v, nil
+		}
+	}
+	return nil, errors.New("Operand must be a number.")
+}
+
+// Converts an operand to a fraction.
+func bigRat(x interface{}) *big.Rat {
+	if i, ok := x.(*big.Int); ok {
+		return new(big.Rat).SetInt(i)
+	}
+	return x.(*big.Rat)
+}
+
+// Stores [x] in slot 0 as a new BigInt or Decimal.
+func bignumReturn(vm *VM, x interface{}) {
+	class := "Decimal"
+	if _, ok := x.(*big.Int); ok {
+		class = "BigInt"
+	}
+	vm.returnObject("bignum", class, x)
+}
+
+func bignumSet(vm *VM) {
+	x, err := bignumOperand(vm, 1)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	switch v := vm.GetSlotObject(0).(type) {
+	case *big.Int:
+		r := bigRat(x)
+		if !r.IsInt() {
+			vm.abortFiber("BigInt must be an integer.")
+			return
+		}
+		v.Set(r.Num())
+	case *big.Rat:
+		v.Set(bigRat(x))
+	}
+}
+
+// Arithmetic on integers when both operands are, or on fractions.
+type bignumOp struct {
+	divides  bool
+	integer  func(a, b *big.Int) *big.Int
+	fraction func(a, b *big.Rat) *big.Rat
+}
+
+var (
+	bignumAdd = bignumOp{
+		integer:  func(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) },
+		fraction: func(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) },
+	}
+	bignumSub = bignumOp{
+		integer:  func(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) },
+		fraction: func(a, b *big.Rat) *big.Rat { return new(big.Rat).Sub(a, b) },
+	}
+	bignumMul = bignumOp{
+		integer:  func(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) },
+		fraction: func(a, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) },
+	}
+	bignumDiv = bignumOp{
+		divides:  true,
+		integer:  func(a, b *big.Int) *big.Int { return new(big.Int).Quo(a, b) },
+		fraction: func(a, b *big.Rat) *big.Rat { return new(big.Rat).Quo(a, b) },
+	}
+	// The remainder has the sign of the dividend, like the one of `%`.
+	bignumMod = bignumOp{
+		divides: true,
+		integer: func(a, b *big.Int) *big.Int { return new(big.Int).Rem(a, b) },
+		fraction: func(a, b *big.Rat) *big.Rat {
+			q := new(big.Rat).Quo(a, b)
+			t := new(big.Rat).SetInt(new(big.Int).Quo(q.Num(), q.Denom()))
+			return new(big.Rat).Sub(a, t.Mul(t, b))
+		},
+	}
+)
+
+func bignumArithmetic(op bignumOp) func(*VM) {
+	return func(vm *VM) {
+		b, err := bignumOperand(vm, 1)
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		if bigRat(b).Sign() == 0 && op.divides {
+			vm.abortFiber("Division by zero.")
+			return
+		}
+
+		a := vm.GetSlotObject(0)
+		ai, aInt := a.(*big.Int)
+		bi, bInt := b.(*big.Int)
+		if aInt && bInt {
+			bignumReturn(vm, op.integer(ai, bi))
+			return
+		}
+		bignumReturn(vm, op.fraction(bigRat(a), bigRat(b)))
+	}
+}
+
+func bignumNeg(vm *VM) {
+	switch v := vm.GetSlotObject(0).(type) {
+	case *big.Int:
+		bignumReturn(vm, new(big.Int).Neg(v))
+	case *big.Rat:
+		bignumReturn(vm, new(big.Rat).Neg(v))
+	}
+}
+
+func bignumCompare(test func(int) bool) func(*VM) {
+	return func(vm *VM) {
+		b, err := bignumOperand(vm, 1)
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		vm.SetSlotBool(0, test(bigRat(vm.GetSlotObject(0)).Cmp(bigRat(b))))
+	}
+}
+
+// Numbers are never equal to other values, rather than aborting.
+func bignumEqual(equal bool) func(*VM) {
+	return func(vm *VM) {
+		b, err := bignumOperand(vm, 1)
+		if err != nil || vm.GetSlotType(1) == WREN_TYPE_STRING {
+			vm.SetSlotBool(0, !equal)
+			return
+		}
+		vm.SetSlotBool(0, (bigRat(vm.GetSlotObject(0)).Cmp(bigRat(b)) == 0) == equal)
+	}
+}
+
+func bignumAbs(vm *VM) {
+	switch v := vm.GetSlotObject(0).(type) {
+	case *big.Int:
+		bignumReturn(vm, new(big.Int).Abs(v))
+	case *big.Rat:
+		bignumReturn(vm, new(big.Rat).Abs(v))
+	}
+}
+
+func bignumSign(vm *VM) {
+	vm.SetSlotDouble(0, float64(bigRat(vm.GetSlotObject(0)).Sign()))
+}
+
+func bignumToNum(vm *VM) {
+	f, _ := bigRat(vm.GetSlotObject(0)).Float64()
+	vm.SetSlotDouble(0, f)
+}
+
+func bignumToString(vm *VM) {
+	switch v := vm.GetSlotObject(0).(type) {
+	case *big.Int:
+		vm.SetSlotString(0, v.String())
+	case *big.Rat:
+		vm.SetSlotString(0, decimalString(v))
+	}
+}
+
+// Formats [r] with as many digits after the point as it needs, or
+// [decimalPrecision] if it has no exact decimal representation.
+func decimalString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	var (
+		d      = new(big.Int).Set(r.Denom())
+		m      = new(big.Int)
+		digits = 0
+	)
+	for _, factor := range []int64{2, 5} {
+		f, n := big.NewInt(factor), 0
+		for {
+			q, rem := new(big.Int).QuoRem(d, f, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			d, n = q, n+1
+		}
+		digits = max(digits, n)
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		s := strings.TrimRight(r.FloatString(decimalPrecision), "0")
+		return strings.TrimSuffix(s, ".")
+	}
+	return r.FloatString(digits)
+}
+
+// Rounds half away from zero.
+func decimalRound(vm *VM) {
+	var (
+		r     = vm.GetSlotObject(0).(*big.Rat)
+		scale = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(vm.GetSlotDouble(1))), nil)
+		x     = new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+		half  = big.NewRat(int64(x.Sign()), 2)
+	)
+	x.Add(x, half)
+	n := new(big.Int).Quo(x.Num(), x.Denom())
+	bignumReturn(vm, new(big.Rat).SetFrac(n, scale))
+}
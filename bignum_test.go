@@ -0,0 +1,46 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBignum(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "bignum" for BigInt, Decimal
+		var a = BigInt.new("9007199254740993")
+		System.print(a + 1)
+		System.print(a * a)
+		System.print([BigInt.new(-7) / 2, BigInt.new(-7) % 2, -a])
+		System.print([a > 1, a == BigInt.new("9007199254740993"), a == "x", a != 1])
+		System.print(BigInt.new(1) / 2.5)
+
+		var price = Decimal.new("0.1") + Decimal.new(0.2)
+		System.print([price, price == Decimal.new("0.3"), price.toNum])
+		System.print([Decimal.new(10) / 3, (Decimal.new(10) / 3).round(2)])
+		System.print([Decimal.new("-2.5").round(0), Decimal.new("7.5") % 2, Decimal.new("1.25").abs.sign])
+	`))
+	assert.Equal(t, `9007199254740994
+81129638414606717966346021941249
+[-3, -1, -9007199254740993]
+[true, true, false, true]
+0.4
+[0.3, true, 0.3]
+[3.33333333333333333333, 3.33]
+[-3, 1.5, 1]
+`, out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `BigInt.new("1.5")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `BigInt.new("abc")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `BigInt.new(1) / 0`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Decimal.new(1) < "x"`))
+}
@@ -1,6 +1,12 @@
 package wrengo
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+)
 
 // Default write callback that outputs to Stdout
 func CallbackWrite(vm *VM, text string) {
@@ -9,5 +15,87 @@ func CallbackWrite(vm *VM, text string) {
 
 // Default error callback that outputs to Stdout
 func CallbackError(vm *VM, errorType ErrorType, module string, line int, message string) {
-	fmt.Println(fmt.Sprint(errorType.String(), " (", module, ") Line ", line, " : ", message))
+	fmt.Println(formatError(errorType, module, line, message))
+}
+
+// Returns an error callback that reports errors to [logger] as structured
+// records. If [logger] is `NULL`, [slog.Default] is used.
+//
+// Compile errors are logged with their module, line and error type. Runtime
+// errors are logged once their stack trace is complete, with the module and
+// line of the innermost frame. Every frame is grouped under "stack" by its
+// position, innermost first, like "stack.0.function".
+func CallbackErrorSlog(logger *slog.Logger) func(vm *VM, errorType ErrorType, module string, line int, message string) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	// The runtime errors whose stack trace is being reported, by VM.
+	var (
+		mu     sync.Mutex
+		traces = make(map[*VM]*slogTrace)
+	)
+
+	return func(vm *VM, errorType ErrorType, module string, line int, message string) {
+		switch errorType {
+		case ERROR_RUNTIME:
+			t := &slogTrace{message: message}
+			mu.Lock()
+			traces[vm] = t
+			mu.Unlock()
+
+			vm.onReported(func() {
+				mu.Lock()
+				delete(traces, vm)
+				mu.Unlock()
+				t.log(logger)
+			})
+		case ERROR_STACK_TRACE:
+			mu.Lock()
+			t := traces[vm]
+			mu.Unlock()
+			if t == nil {
+				return
+			}
+			t.stack = append(t.stack, slog.Group(strconv.Itoa(len(t.stack)),
+				slog.String("module", module),
+				slog.Int("line", line),
+				slog.String("function", message),
+			))
+			if len(t.stack) == 1 {
+				t.module, t.line = module, line
+			}
+		default:
+			logger.LogAttrs(context.Background(), slog.LevelError, message,
+				slog.String("type", errorType.String()),
+				slog.String("module", module),
+				slog.Int("line", line),
+			)
+		}
+	}
+}
+
+// A runtime error reported to [CallbackErrorSlog], with its stack trace so far.
+type slogTrace struct {
+	message string
+	module  string
+	line    int
+	stack   []interface{}
+}
+
+func (t *slogTrace) log(logger *slog.Logger) {
+	attrs := []slog.Attr{slog.String("type", ERROR_RUNTIME.String())}
+	if len(t.stack) > 0 {
+		attrs = append(attrs,
+			slog.String("module", t.module),
+			slog.Int("line", t.line),
+			slog.Group("stack", t.stack...),
+		)
+	}
+	logger.LogAttrs(context.Background(), slog.LevelError, t.message, attrs...)
+}
+
+// Formats an error the way [CallbackError] prints it.
+func formatError(errorType ErrorType, module string, line int, message string) string {
+	return fmt.Sprint(errorType.String(), " (", module, ") Line ", line, " : ", message)
 }
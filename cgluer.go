@@ -26,6 +26,7 @@ static inline void* get_f(int i) {
 import "C"
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -41,22 +42,30 @@ var (
 {{range .}}
 //export f{{.}}
 func f{{.}}(vm *C.WrenVM) {
-	f := fMap[{{.}}]
+	callFunc({{.}}, vm)
+}
+{{end}}
+
+// Functions may be registered by one VM while another one is calling them.
+func callFunc(i int, vm *C.WrenVM) {
+	fMapGuard.Lock()
+	f := fMap[i]
+	fMapGuard.Unlock()
+
 	if f == nil {
-		panic("function {{.}} not registered")
+		panic(fmt.Sprintf("function %d not registered", i))
 	}
-	f(vmMap[vm])
+	f(lookupVM(vm))
 }
-{{end}}
 
 func registerFunc(name string, f func(*VM)) (unsafe.Pointer, error) {
+	fMapGuard.Lock()
+	defer fMapGuard.Unlock()
+
 	if (fCounter+1) >= MAX_FUNC_REGISTRATIONS {
 		return nil, errors.New("maximum function registration reached")
 	}
 
-	fMapGuard.Lock()
-	defer fMapGuard.Unlock()
-
 	fMap[fCounter] = f
 	ptr := C.get_f(C.int(fCounter))
 	fCounter++
@@ -80,6 +89,7 @@ static inline void* get_c(int i) {
 import "C"
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -95,22 +105,30 @@ var (
 {{range .}}
 //export c{{.}}
 func c{{.}}(vm unsafe.Pointer) {
-	f := cMap[{{.}}]
+	callClass({{.}})
+}
+{{end}}
+
+// Classes may be registered by one VM while another one is allocating them.
+func callClass(i int) {
+	cMapGuard.Lock()
+	f := cMap[i]
+	cMapGuard.Unlock()
+
 	if f == nil {
-		panic("function {{.}} not registered")
+		panic(fmt.Sprintf("function %d not registered", i))
 	}
 	f()
 }
-{{end}}
 
 func registerClass(name string, f func()) (unsafe.Pointer, error) {
+	cMapGuard.Lock()
+	defer cMapGuard.Unlock()
+
 	if (cCounter+1) >= MAX_CLASS_REGISTRATIONS {
 		return nil, errors.New("maximum function registration reached")
 	}
 
-	cMapGuard.Lock()
-	defer cMapGuard.Unlock()
-
 	cMap[cCounter] = f
 	ptr := C.get_c(C.int(cCounter))
 	cCounter++
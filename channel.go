@@ -0,0 +1,139 @@
+package wrengo
+
+import (
+	"context"
+	"errors"
+)
+
+// The built-in "channel" module, letting fibers exchange values with Go
+// through channels made by [VM.NewChannel].
+//
+// Sending and receiving suspend the calling fiber until the channel is ready,
+// see [VM.RunLoop]:
+//
+//	import "channel" for Channel
+//
+//	var double = Fn.new {|input, output|
+//	  var value
+//	  while ((value = input.receive()) != null) output.send(value * 2)
+//	  output.close()
+//	}
+//
+// Receiving from a closed channel returns null, while sending to one or
+// closing it again aborts the fiber.
+var channelModule = &Module{
+	Name: "channel",
+	Source: `
+import "scheduler" for Scheduler
+import "wrengo/value" for Value
+
+foreign class Channel {
+  send(value) {
+    send_(Value.encode(value), Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  receive() {
+    receive_(Fiber.current)
+    return Value.decode(Scheduler.runNextScheduled_())
+  }
+
+  foreign close()
+
+  foreign send_(value, fiber)
+  foreign receive_(fiber)
+}
+`,
+	Methods: map[string]func(*VM){
+		"Channel.close()":     channelClose,
+		"Channel.send_(_,_)":  channelSend,
+		"Channel.receive_(_)": channelReceive,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		// Instances are only created by [VM.NewChannel].
+		"Channel": func(*VM) interface{} { return nil },
+	},
+}
+
+var errClosedChannel = errors.New("Channel is closed.")
+
+// Wraps [ch] in a Channel for scripts, so their fibers can send values to Go
+// and receive values from it.
+//
+// Values are converted as by [SetSlotValue] and [GetSlotValue], except that
+// maps are supported too and become map[interface{}]interface{}.
+//
+// The returned handle must be released with [Release] when no longer needed,
+// and this must not be called from a foreign method.
+func (vm *VM) NewChannel(ch chan interface{}) (Handle, error) {
+	if err := vm.require(channelModule); err != nil {
+		return Handle{}, err
+	}
+
+	vm.EnsureSlots(2)
+	vm.GetVariable(channelModule.Name, "Channel", 1)
+	vm.SetSlotNewObject(0, 1, ch)
+	return vm.GetSlotHandle(0), nil
+}
+
+func channelOf(vm *VM) chan interface{} {
+	return vm.GetSlotObject(0).(chan interface{})
+}
+
+func channelClose(vm *VM) {
+	if err := closeChannel(channelOf(vm)); err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNull(0)
+}
+
+// Closes [ch], returning an error instead of panicking if it already is.
+func closeChannel(ch chan interface{}) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = errClosedChannel
+		}
+	}()
+	close(ch)
+	return nil
+}
+
+func channelSend(vm *VM) {
+	ch := channelOf(vm)
+	value, err := vm.getSlotEncoded(1)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	vm.Async(2, func(ctx context.Context) (_ interface{}, err error) {
+		// Go panics when sending to a closed channel.
+		defer func() {
+			if recover() != nil {
+				err = errClosedChannel
+			}
+		}()
+
+		select {
+		case ch <- value:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
+
+func channelReceive(vm *VM) {
+	ch := channelOf(vm)
+	vm.Async(1, func(ctx context.Context) (interface{}, error) {
+		select {
+		case value := <-ch:
+			return encodedValue{value}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
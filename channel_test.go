@@ -0,0 +1,62 @@
+package wrengo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannel(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new {|input, output|
+		var value
+		while ((value = input.receive()) != null) {
+			output.send({"doubled": value * 2})
+		}
+		output.send([input.receive()])
+		output.close()
+	}`)
+	defer fn.Release()
+
+	in, out := make(chan interface{}, 3), make(chan interface{}, 5)
+	in <- 1
+	in <- 2
+	close(in)
+
+	input, err := vm.NewChannel(in)
+	assert.NoError(t, err)
+	defer input.Release()
+	output, err := vm.NewChannel(out)
+	assert.NoError(t, err)
+	defer output.Release()
+
+	call := vm.NewCallHandle("call(_,_)")
+	defer call.Release()
+	vm.EnsureSlots(3)
+	vm.SetSlotHandle(0, fn)
+	vm.SetSlotHandle(1, input)
+	vm.SetSlotHandle(2, output)
+	assert.NoError(t, call.Call())
+	assert.NoError(t, vm.RunLoop(context.Background()))
+
+	var values []interface{}
+	for value := range out {
+		values = append(values, value)
+	}
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{"doubled": 2.0},
+		map[interface{}]interface{}{"doubled": 4.0},
+		[]interface{}{nil},
+	}, values)
+
+	vm.EnsureSlots(2)
+	vm.SetSlotHandle(0, output)
+	vm.SetSlotString(1, "late")
+	send := vm.NewCallHandle("send(_)")
+	defer send.Release()
+	assert.NoError(t, send.Call())
+	assert.Error(t, vm.RunLoop(context.Background()))
+}
@@ -524,6 +524,7 @@ static inline void* get_c(int i) {
 import "C"
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -539,2317 +540,1305 @@ var (
 
 //export c0
 func c0(vm unsafe.Pointer) {
-	f := cMap[0]
-	if f == nil {
-		panic("function 0 not registered")
-	}
-	f()
+	callClass(0)
 }
 
 //export c1
 func c1(vm unsafe.Pointer) {
-	f := cMap[1]
-	if f == nil {
-		panic("function 1 not registered")
-	}
-	f()
+	callClass(1)
 }
 
 //export c2
 func c2(vm unsafe.Pointer) {
-	f := cMap[2]
-	if f == nil {
-		panic("function 2 not registered")
-	}
-	f()
+	callClass(2)
 }
 
 //export c3
 func c3(vm unsafe.Pointer) {
-	f := cMap[3]
-	if f == nil {
-		panic("function 3 not registered")
-	}
-	f()
+	callClass(3)
 }
 
 //export c4
 func c4(vm unsafe.Pointer) {
-	f := cMap[4]
-	if f == nil {
-		panic("function 4 not registered")
-	}
-	f()
+	callClass(4)
 }
 
 //export c5
 func c5(vm unsafe.Pointer) {
-	f := cMap[5]
-	if f == nil {
-		panic("function 5 not registered")
-	}
-	f()
+	callClass(5)
 }
 
 //export c6
 func c6(vm unsafe.Pointer) {
-	f := cMap[6]
-	if f == nil {
-		panic("function 6 not registered")
-	}
-	f()
+	callClass(6)
 }
 
 //export c7
 func c7(vm unsafe.Pointer) {
-	f := cMap[7]
-	if f == nil {
-		panic("function 7 not registered")
-	}
-	f()
+	callClass(7)
 }
 
 //export c8
 func c8(vm unsafe.Pointer) {
-	f := cMap[8]
-	if f == nil {
-		panic("function 8 not registered")
-	}
-	f()
+	callClass(8)
 }
 
 //export c9
 func c9(vm unsafe.Pointer) {
-	f := cMap[9]
-	if f == nil {
-		panic("function 9 not registered")
-	}
-	f()
+	callClass(9)
 }
 
 //export c10
 func c10(vm unsafe.Pointer) {
-	f := cMap[10]
-	if f == nil {
-		panic("function 10 not registered")
-	}
-	f()
+	callClass(10)
 }
 
 //export c11
 func c11(vm unsafe.Pointer) {
-	f := cMap[11]
-	if f == nil {
-		panic("function 11 not registered")
-	}
-	f()
+	callClass(11)
 }
 
 //export c12
 func c12(vm unsafe.Pointer) {
-	f := cMap[12]
-	if f == nil {
-		panic("function 12 not registered")
-	}
-	f()
+	callClass(12)
 }
 
 //export c13
 func c13(vm unsafe.Pointer) {
-	f := cMap[13]
-	if f == nil {
-		panic("function 13 not registered")
-	}
-	f()
+	callClass(13)
 }
 
 //export c14
 func c14(vm unsafe.Pointer) {
-	f := cMap[14]
-	if f == nil {
-		panic("function 14 not registered")
-	}
-	f()
+	callClass(14)
 }
 
 //export c15
 func c15(vm unsafe.Pointer) {
-	f := cMap[15]
-	if f == nil {
-		panic("function 15 not registered")
-	}
-	f()
+	callClass(15)
 }
 
 //export c16
 func c16(vm unsafe.Pointer) {
-	f := cMap[16]
-	if f == nil {
-		panic("function 16 not registered")
-	}
-	f()
+	callClass(16)
 }
 
 //export c17
 func c17(vm unsafe.Pointer) {
-	f := cMap[17]
-	if f == nil {
-		panic("function 17 not registered")
-	}
-	f()
+	callClass(17)
 }
 
 //export c18
 func c18(vm unsafe.Pointer) {
-	f := cMap[18]
-	if f == nil {
-		panic("function 18 not registered")
-	}
-	f()
+	callClass(18)
 }
 
 //export c19
 func c19(vm unsafe.Pointer) {
-	f := cMap[19]
-	if f == nil {
-		panic("function 19 not registered")
-	}
-	f()
+	callClass(19)
 }
 
 //export c20
 func c20(vm unsafe.Pointer) {
-	f := cMap[20]
-	if f == nil {
-		panic("function 20 not registered")
-	}
-	f()
+	callClass(20)
 }
 
 //export c21
 func c21(vm unsafe.Pointer) {
-	f := cMap[21]
-	if f == nil {
-		panic("function 21 not registered")
-	}
-	f()
+	callClass(21)
 }
 
 //export c22
 func c22(vm unsafe.Pointer) {
-	f := cMap[22]
-	if f == nil {
-		panic("function 22 not registered")
-	}
-	f()
+	callClass(22)
 }
 
 //export c23
 func c23(vm unsafe.Pointer) {
-	f := cMap[23]
-	if f == nil {
-		panic("function 23 not registered")
-	}
-	f()
+	callClass(23)
 }
 
 //export c24
 func c24(vm unsafe.Pointer) {
-	f := cMap[24]
-	if f == nil {
-		panic("function 24 not registered")
-	}
-	f()
+	callClass(24)
 }
 
 //export c25
 func c25(vm unsafe.Pointer) {
-	f := cMap[25]
-	if f == nil {
-		panic("function 25 not registered")
-	}
-	f()
+	callClass(25)
 }
 
 //export c26
 func c26(vm unsafe.Pointer) {
-	f := cMap[26]
-	if f == nil {
-		panic("function 26 not registered")
-	}
-	f()
+	callClass(26)
 }
 
 //export c27
 func c27(vm unsafe.Pointer) {
-	f := cMap[27]
-	if f == nil {
-		panic("function 27 not registered")
-	}
-	f()
+	callClass(27)
 }
 
 //export c28
 func c28(vm unsafe.Pointer) {
-	f := cMap[28]
-	if f == nil {
-		panic("function 28 not registered")
-	}
-	f()
+	callClass(28)
 }
 
 //export c29
 func c29(vm unsafe.Pointer) {
-	f := cMap[29]
-	if f == nil {
-		panic("function 29 not registered")
-	}
-	f()
+	callClass(29)
 }
 
 //export c30
 func c30(vm unsafe.Pointer) {
-	f := cMap[30]
-	if f == nil {
-		panic("function 30 not registered")
-	}
-	f()
+	callClass(30)
 }
 
 //export c31
 func c31(vm unsafe.Pointer) {
-	f := cMap[31]
-	if f == nil {
-		panic("function 31 not registered")
-	}
-	f()
+	callClass(31)
 }
 
 //export c32
 func c32(vm unsafe.Pointer) {
-	f := cMap[32]
-	if f == nil {
-		panic("function 32 not registered")
-	}
-	f()
+	callClass(32)
 }
 
 //export c33
 func c33(vm unsafe.Pointer) {
-	f := cMap[33]
-	if f == nil {
-		panic("function 33 not registered")
-	}
-	f()
+	callClass(33)
 }
 
 //export c34
 func c34(vm unsafe.Pointer) {
-	f := cMap[34]
-	if f == nil {
-		panic("function 34 not registered")
-	}
-	f()
+	callClass(34)
 }
 
 //export c35
 func c35(vm unsafe.Pointer) {
-	f := cMap[35]
-	if f == nil {
-		panic("function 35 not registered")
-	}
-	f()
+	callClass(35)
 }
 
 //export c36
 func c36(vm unsafe.Pointer) {
-	f := cMap[36]
-	if f == nil {
-		panic("function 36 not registered")
-	}
-	f()
+	callClass(36)
 }
 
 //export c37
 func c37(vm unsafe.Pointer) {
-	f := cMap[37]
-	if f == nil {
-		panic("function 37 not registered")
-	}
-	f()
+	callClass(37)
 }
 
 //export c38
 func c38(vm unsafe.Pointer) {
-	f := cMap[38]
-	if f == nil {
-		panic("function 38 not registered")
-	}
-	f()
+	callClass(38)
 }
 
 //export c39
 func c39(vm unsafe.Pointer) {
-	f := cMap[39]
-	if f == nil {
-		panic("function 39 not registered")
-	}
-	f()
+	callClass(39)
 }
 
 //export c40
 func c40(vm unsafe.Pointer) {
-	f := cMap[40]
-	if f == nil {
-		panic("function 40 not registered")
-	}
-	f()
+	callClass(40)
 }
 
 //export c41
 func c41(vm unsafe.Pointer) {
-	f := cMap[41]
-	if f == nil {
-		panic("function 41 not registered")
-	}
-	f()
+	callClass(41)
 }
 
 //export c42
 func c42(vm unsafe.Pointer) {
-	f := cMap[42]
-	if f == nil {
-		panic("function 42 not registered")
-	}
-	f()
+	callClass(42)
 }
 
 //export c43
 func c43(vm unsafe.Pointer) {
-	f := cMap[43]
-	if f == nil {
-		panic("function 43 not registered")
-	}
-	f()
+	callClass(43)
 }
 
 //export c44
 func c44(vm unsafe.Pointer) {
-	f := cMap[44]
-	if f == nil {
-		panic("function 44 not registered")
-	}
-	f()
+	callClass(44)
 }
 
 //export c45
 func c45(vm unsafe.Pointer) {
-	f := cMap[45]
-	if f == nil {
-		panic("function 45 not registered")
-	}
-	f()
+	callClass(45)
 }
 
 //export c46
 func c46(vm unsafe.Pointer) {
-	f := cMap[46]
-	if f == nil {
-		panic("function 46 not registered")
-	}
-	f()
+	callClass(46)
 }
 
 //export c47
 func c47(vm unsafe.Pointer) {
-	f := cMap[47]
-	if f == nil {
-		panic("function 47 not registered")
-	}
-	f()
+	callClass(47)
 }
 
 //export c48
 func c48(vm unsafe.Pointer) {
-	f := cMap[48]
-	if f == nil {
-		panic("function 48 not registered")
-	}
-	f()
+	callClass(48)
 }
 
 //export c49
 func c49(vm unsafe.Pointer) {
-	f := cMap[49]
-	if f == nil {
-		panic("function 49 not registered")
-	}
-	f()
+	callClass(49)
 }
 
 //export c50
 func c50(vm unsafe.Pointer) {
-	f := cMap[50]
-	if f == nil {
-		panic("function 50 not registered")
-	}
-	f()
+	callClass(50)
 }
 
 //export c51
 func c51(vm unsafe.Pointer) {
-	f := cMap[51]
-	if f == nil {
-		panic("function 51 not registered")
-	}
-	f()
+	callClass(51)
 }
 
 //export c52
 func c52(vm unsafe.Pointer) {
-	f := cMap[52]
-	if f == nil {
-		panic("function 52 not registered")
-	}
-	f()
+	callClass(52)
 }
 
 //export c53
 func c53(vm unsafe.Pointer) {
-	f := cMap[53]
-	if f == nil {
-		panic("function 53 not registered")
-	}
-	f()
+	callClass(53)
 }
 
 //export c54
 func c54(vm unsafe.Pointer) {
-	f := cMap[54]
-	if f == nil {
-		panic("function 54 not registered")
-	}
-	f()
+	callClass(54)
 }
 
 //export c55
 func c55(vm unsafe.Pointer) {
-	f := cMap[55]
-	if f == nil {
-		panic("function 55 not registered")
-	}
-	f()
+	callClass(55)
 }
 
 //export c56
 func c56(vm unsafe.Pointer) {
-	f := cMap[56]
-	if f == nil {
-		panic("function 56 not registered")
-	}
-	f()
+	callClass(56)
 }
 
 //export c57
 func c57(vm unsafe.Pointer) {
-	f := cMap[57]
-	if f == nil {
-		panic("function 57 not registered")
-	}
-	f()
+	callClass(57)
 }
 
 //export c58
 func c58(vm unsafe.Pointer) {
-	f := cMap[58]
-	if f == nil {
-		panic("function 58 not registered")
-	}
-	f()
+	callClass(58)
 }
 
 //export c59
 func c59(vm unsafe.Pointer) {
-	f := cMap[59]
-	if f == nil {
-		panic("function 59 not registered")
-	}
-	f()
+	callClass(59)
 }
 
 //export c60
 func c60(vm unsafe.Pointer) {
-	f := cMap[60]
-	if f == nil {
-		panic("function 60 not registered")
-	}
-	f()
+	callClass(60)
 }
 
 //export c61
 func c61(vm unsafe.Pointer) {
-	f := cMap[61]
-	if f == nil {
-		panic("function 61 not registered")
-	}
-	f()
+	callClass(61)
 }
 
 //export c62
 func c62(vm unsafe.Pointer) {
-	f := cMap[62]
-	if f == nil {
-		panic("function 62 not registered")
-	}
-	f()
+	callClass(62)
 }
 
 //export c63
 func c63(vm unsafe.Pointer) {
-	f := cMap[63]
-	if f == nil {
-		panic("function 63 not registered")
-	}
-	f()
+	callClass(63)
 }
 
 //export c64
 func c64(vm unsafe.Pointer) {
-	f := cMap[64]
-	if f == nil {
-		panic("function 64 not registered")
-	}
-	f()
+	callClass(64)
 }
 
 //export c65
 func c65(vm unsafe.Pointer) {
-	f := cMap[65]
-	if f == nil {
-		panic("function 65 not registered")
-	}
-	f()
+	callClass(65)
 }
 
 //export c66
 func c66(vm unsafe.Pointer) {
-	f := cMap[66]
-	if f == nil {
-		panic("function 66 not registered")
-	}
-	f()
+	callClass(66)
 }
 
 //export c67
 func c67(vm unsafe.Pointer) {
-	f := cMap[67]
-	if f == nil {
-		panic("function 67 not registered")
-	}
-	f()
+	callClass(67)
 }
 
 //export c68
 func c68(vm unsafe.Pointer) {
-	f := cMap[68]
-	if f == nil {
-		panic("function 68 not registered")
-	}
-	f()
+	callClass(68)
 }
 
 //export c69
 func c69(vm unsafe.Pointer) {
-	f := cMap[69]
-	if f == nil {
-		panic("function 69 not registered")
-	}
-	f()
+	callClass(69)
 }
 
 //export c70
 func c70(vm unsafe.Pointer) {
-	f := cMap[70]
-	if f == nil {
-		panic("function 70 not registered")
-	}
-	f()
+	callClass(70)
 }
 
 //export c71
 func c71(vm unsafe.Pointer) {
-	f := cMap[71]
-	if f == nil {
-		panic("function 71 not registered")
-	}
-	f()
+	callClass(71)
 }
 
 //export c72
 func c72(vm unsafe.Pointer) {
-	f := cMap[72]
-	if f == nil {
-		panic("function 72 not registered")
-	}
-	f()
+	callClass(72)
 }
 
 //export c73
 func c73(vm unsafe.Pointer) {
-	f := cMap[73]
-	if f == nil {
-		panic("function 73 not registered")
-	}
-	f()
+	callClass(73)
 }
 
 //export c74
 func c74(vm unsafe.Pointer) {
-	f := cMap[74]
-	if f == nil {
-		panic("function 74 not registered")
-	}
-	f()
+	callClass(74)
 }
 
 //export c75
 func c75(vm unsafe.Pointer) {
-	f := cMap[75]
-	if f == nil {
-		panic("function 75 not registered")
-	}
-	f()
+	callClass(75)
 }
 
 //export c76
 func c76(vm unsafe.Pointer) {
-	f := cMap[76]
-	if f == nil {
-		panic("function 76 not registered")
-	}
-	f()
+	callClass(76)
 }
 
 //export c77
 func c77(vm unsafe.Pointer) {
-	f := cMap[77]
-	if f == nil {
-		panic("function 77 not registered")
-	}
-	f()
+	callClass(77)
 }
 
 //export c78
 func c78(vm unsafe.Pointer) {
-	f := cMap[78]
-	if f == nil {
-		panic("function 78 not registered")
-	}
-	f()
+	callClass(78)
 }
 
 //export c79
 func c79(vm unsafe.Pointer) {
-	f := cMap[79]
-	if f == nil {
-		panic("function 79 not registered")
-	}
-	f()
+	callClass(79)
 }
 
 //export c80
 func c80(vm unsafe.Pointer) {
-	f := cMap[80]
-	if f == nil {
-		panic("function 80 not registered")
-	}
-	f()
+	callClass(80)
 }
 
 //export c81
 func c81(vm unsafe.Pointer) {
-	f := cMap[81]
-	if f == nil {
-		panic("function 81 not registered")
-	}
-	f()
+	callClass(81)
 }
 
 //export c82
 func c82(vm unsafe.Pointer) {
-	f := cMap[82]
-	if f == nil {
-		panic("function 82 not registered")
-	}
-	f()
+	callClass(82)
 }
 
 //export c83
 func c83(vm unsafe.Pointer) {
-	f := cMap[83]
-	if f == nil {
-		panic("function 83 not registered")
-	}
-	f()
+	callClass(83)
 }
 
 //export c84
 func c84(vm unsafe.Pointer) {
-	f := cMap[84]
-	if f == nil {
-		panic("function 84 not registered")
-	}
-	f()
+	callClass(84)
 }
 
 //export c85
 func c85(vm unsafe.Pointer) {
-	f := cMap[85]
-	if f == nil {
-		panic("function 85 not registered")
-	}
-	f()
+	callClass(85)
 }
 
 //export c86
 func c86(vm unsafe.Pointer) {
-	f := cMap[86]
-	if f == nil {
-		panic("function 86 not registered")
-	}
-	f()
+	callClass(86)
 }
 
 //export c87
 func c87(vm unsafe.Pointer) {
-	f := cMap[87]
-	if f == nil {
-		panic("function 87 not registered")
-	}
-	f()
+	callClass(87)
 }
 
 //export c88
 func c88(vm unsafe.Pointer) {
-	f := cMap[88]
-	if f == nil {
-		panic("function 88 not registered")
-	}
-	f()
+	callClass(88)
 }
 
 //export c89
 func c89(vm unsafe.Pointer) {
-	f := cMap[89]
-	if f == nil {
-		panic("function 89 not registered")
-	}
-	f()
+	callClass(89)
 }
 
 //export c90
 func c90(vm unsafe.Pointer) {
-	f := cMap[90]
-	if f == nil {
-		panic("function 90 not registered")
-	}
-	f()
+	callClass(90)
 }
 
 //export c91
 func c91(vm unsafe.Pointer) {
-	f := cMap[91]
-	if f == nil {
-		panic("function 91 not registered")
-	}
-	f()
+	callClass(91)
 }
 
 //export c92
 func c92(vm unsafe.Pointer) {
-	f := cMap[92]
-	if f == nil {
-		panic("function 92 not registered")
-	}
-	f()
+	callClass(92)
 }
 
 //export c93
 func c93(vm unsafe.Pointer) {
-	f := cMap[93]
-	if f == nil {
-		panic("function 93 not registered")
-	}
-	f()
+	callClass(93)
 }
 
 //export c94
 func c94(vm unsafe.Pointer) {
-	f := cMap[94]
-	if f == nil {
-		panic("function 94 not registered")
-	}
-	f()
+	callClass(94)
 }
 
 //export c95
 func c95(vm unsafe.Pointer) {
-	f := cMap[95]
-	if f == nil {
-		panic("function 95 not registered")
-	}
-	f()
+	callClass(95)
 }
 
 //export c96
 func c96(vm unsafe.Pointer) {
-	f := cMap[96]
-	if f == nil {
-		panic("function 96 not registered")
-	}
-	f()
+	callClass(96)
 }
 
 //export c97
 func c97(vm unsafe.Pointer) {
-	f := cMap[97]
-	if f == nil {
-		panic("function 97 not registered")
-	}
-	f()
+	callClass(97)
 }
 
 //export c98
 func c98(vm unsafe.Pointer) {
-	f := cMap[98]
-	if f == nil {
-		panic("function 98 not registered")
-	}
-	f()
+	callClass(98)
 }
 
 //export c99
 func c99(vm unsafe.Pointer) {
-	f := cMap[99]
-	if f == nil {
-		panic("function 99 not registered")
-	}
-	f()
+	callClass(99)
 }
 
 //export c100
 func c100(vm unsafe.Pointer) {
-	f := cMap[100]
-	if f == nil {
-		panic("function 100 not registered")
-	}
-	f()
+	callClass(100)
 }
 
 //export c101
 func c101(vm unsafe.Pointer) {
-	f := cMap[101]
-	if f == nil {
-		panic("function 101 not registered")
-	}
-	f()
+	callClass(101)
 }
 
 //export c102
 func c102(vm unsafe.Pointer) {
-	f := cMap[102]
-	if f == nil {
-		panic("function 102 not registered")
-	}
-	f()
+	callClass(102)
 }
 
 //export c103
 func c103(vm unsafe.Pointer) {
-	f := cMap[103]
-	if f == nil {
-		panic("function 103 not registered")
-	}
-	f()
+	callClass(103)
 }
 
 //export c104
 func c104(vm unsafe.Pointer) {
-	f := cMap[104]
-	if f == nil {
-		panic("function 104 not registered")
-	}
-	f()
+	callClass(104)
 }
 
 //export c105
 func c105(vm unsafe.Pointer) {
-	f := cMap[105]
-	if f == nil {
-		panic("function 105 not registered")
-	}
-	f()
+	callClass(105)
 }
 
 //export c106
 func c106(vm unsafe.Pointer) {
-	f := cMap[106]
-	if f == nil {
-		panic("function 106 not registered")
-	}
-	f()
+	callClass(106)
 }
 
 //export c107
 func c107(vm unsafe.Pointer) {
-	f := cMap[107]
-	if f == nil {
-		panic("function 107 not registered")
-	}
-	f()
+	callClass(107)
 }
 
 //export c108
 func c108(vm unsafe.Pointer) {
-	f := cMap[108]
-	if f == nil {
-		panic("function 108 not registered")
-	}
-	f()
+	callClass(108)
 }
 
 //export c109
 func c109(vm unsafe.Pointer) {
-	f := cMap[109]
-	if f == nil {
-		panic("function 109 not registered")
-	}
-	f()
+	callClass(109)
 }
 
 //export c110
 func c110(vm unsafe.Pointer) {
-	f := cMap[110]
-	if f == nil {
-		panic("function 110 not registered")
-	}
-	f()
+	callClass(110)
 }
 
 //export c111
 func c111(vm unsafe.Pointer) {
-	f := cMap[111]
-	if f == nil {
-		panic("function 111 not registered")
-	}
-	f()
+	callClass(111)
 }
 
 //export c112
 func c112(vm unsafe.Pointer) {
-	f := cMap[112]
-	if f == nil {
-		panic("function 112 not registered")
-	}
-	f()
+	callClass(112)
 }
 
 //export c113
 func c113(vm unsafe.Pointer) {
-	f := cMap[113]
-	if f == nil {
-		panic("function 113 not registered")
-	}
-	f()
+	callClass(113)
 }
 
 //export c114
 func c114(vm unsafe.Pointer) {
-	f := cMap[114]
-	if f == nil {
-		panic("function 114 not registered")
-	}
-	f()
+	callClass(114)
 }
 
 //export c115
 func c115(vm unsafe.Pointer) {
-	f := cMap[115]
-	if f == nil {
-		panic("function 115 not registered")
-	}
-	f()
+	callClass(115)
 }
 
 //export c116
 func c116(vm unsafe.Pointer) {
-	f := cMap[116]
-	if f == nil {
-		panic("function 116 not registered")
-	}
-	f()
+	callClass(116)
 }
 
 //export c117
 func c117(vm unsafe.Pointer) {
-	f := cMap[117]
-	if f == nil {
-		panic("function 117 not registered")
-	}
-	f()
+	callClass(117)
 }
 
 //export c118
 func c118(vm unsafe.Pointer) {
-	f := cMap[118]
-	if f == nil {
-		panic("function 118 not registered")
-	}
-	f()
+	callClass(118)
 }
 
 //export c119
 func c119(vm unsafe.Pointer) {
-	f := cMap[119]
-	if f == nil {
-		panic("function 119 not registered")
-	}
-	f()
+	callClass(119)
 }
 
 //export c120
 func c120(vm unsafe.Pointer) {
-	f := cMap[120]
-	if f == nil {
-		panic("function 120 not registered")
-	}
-	f()
+	callClass(120)
 }
 
 //export c121
 func c121(vm unsafe.Pointer) {
-	f := cMap[121]
-	if f == nil {
-		panic("function 121 not registered")
-	}
-	f()
+	callClass(121)
 }
 
 //export c122
 func c122(vm unsafe.Pointer) {
-	f := cMap[122]
-	if f == nil {
-		panic("function 122 not registered")
-	}
-	f()
+	callClass(122)
 }
 
 //export c123
 func c123(vm unsafe.Pointer) {
-	f := cMap[123]
-	if f == nil {
-		panic("function 123 not registered")
-	}
-	f()
+	callClass(123)
 }
 
 //export c124
 func c124(vm unsafe.Pointer) {
-	f := cMap[124]
-	if f == nil {
-		panic("function 124 not registered")
-	}
-	f()
+	callClass(124)
 }
 
 //export c125
 func c125(vm unsafe.Pointer) {
-	f := cMap[125]
-	if f == nil {
-		panic("function 125 not registered")
-	}
-	f()
+	callClass(125)
 }
 
 //export c126
 func c126(vm unsafe.Pointer) {
-	f := cMap[126]
-	if f == nil {
-		panic("function 126 not registered")
-	}
-	f()
+	callClass(126)
 }
 
 //export c127
 func c127(vm unsafe.Pointer) {
-	f := cMap[127]
-	if f == nil {
-		panic("function 127 not registered")
-	}
-	f()
+	callClass(127)
 }
 
 //export c128
 func c128(vm unsafe.Pointer) {
-	f := cMap[128]
-	if f == nil {
-		panic("function 128 not registered")
-	}
-	f()
+	callClass(128)
 }
 
 //export c129
 func c129(vm unsafe.Pointer) {
-	f := cMap[129]
-	if f == nil {
-		panic("function 129 not registered")
-	}
-	f()
+	callClass(129)
 }
 
 //export c130
 func c130(vm unsafe.Pointer) {
-	f := cMap[130]
-	if f == nil {
-		panic("function 130 not registered")
-	}
-	f()
+	callClass(130)
 }
 
 //export c131
 func c131(vm unsafe.Pointer) {
-	f := cMap[131]
-	if f == nil {
-		panic("function 131 not registered")
-	}
-	f()
+	callClass(131)
 }
 
 //export c132
 func c132(vm unsafe.Pointer) {
-	f := cMap[132]
-	if f == nil {
-		panic("function 132 not registered")
-	}
-	f()
+	callClass(132)
 }
 
 //export c133
 func c133(vm unsafe.Pointer) {
-	f := cMap[133]
-	if f == nil {
-		panic("function 133 not registered")
-	}
-	f()
+	callClass(133)
 }
 
 //export c134
 func c134(vm unsafe.Pointer) {
-	f := cMap[134]
-	if f == nil {
-		panic("function 134 not registered")
-	}
-	f()
+	callClass(134)
 }
 
 //export c135
 func c135(vm unsafe.Pointer) {
-	f := cMap[135]
-	if f == nil {
-		panic("function 135 not registered")
-	}
-	f()
+	callClass(135)
 }
 
 //export c136
 func c136(vm unsafe.Pointer) {
-	f := cMap[136]
-	if f == nil {
-		panic("function 136 not registered")
-	}
-	f()
+	callClass(136)
 }
 
 //export c137
 func c137(vm unsafe.Pointer) {
-	f := cMap[137]
-	if f == nil {
-		panic("function 137 not registered")
-	}
-	f()
+	callClass(137)
 }
 
 //export c138
 func c138(vm unsafe.Pointer) {
-	f := cMap[138]
-	if f == nil {
-		panic("function 138 not registered")
-	}
-	f()
+	callClass(138)
 }
 
 //export c139
 func c139(vm unsafe.Pointer) {
-	f := cMap[139]
-	if f == nil {
-		panic("function 139 not registered")
-	}
-	f()
+	callClass(139)
 }
 
 //export c140
 func c140(vm unsafe.Pointer) {
-	f := cMap[140]
-	if f == nil {
-		panic("function 140 not registered")
-	}
-	f()
+	callClass(140)
 }
 
 //export c141
 func c141(vm unsafe.Pointer) {
-	f := cMap[141]
-	if f == nil {
-		panic("function 141 not registered")
-	}
-	f()
+	callClass(141)
 }
 
 //export c142
 func c142(vm unsafe.Pointer) {
-	f := cMap[142]
-	if f == nil {
-		panic("function 142 not registered")
-	}
-	f()
+	callClass(142)
 }
 
 //export c143
 func c143(vm unsafe.Pointer) {
-	f := cMap[143]
-	if f == nil {
-		panic("function 143 not registered")
-	}
-	f()
+	callClass(143)
 }
 
 //export c144
 func c144(vm unsafe.Pointer) {
-	f := cMap[144]
-	if f == nil {
-		panic("function 144 not registered")
-	}
-	f()
+	callClass(144)
 }
 
 //export c145
 func c145(vm unsafe.Pointer) {
-	f := cMap[145]
-	if f == nil {
-		panic("function 145 not registered")
-	}
-	f()
+	callClass(145)
 }
 
 //export c146
 func c146(vm unsafe.Pointer) {
-	f := cMap[146]
-	if f == nil {
-		panic("function 146 not registered")
-	}
-	f()
+	callClass(146)
 }
 
 //export c147
 func c147(vm unsafe.Pointer) {
-	f := cMap[147]
-	if f == nil {
-		panic("function 147 not registered")
-	}
-	f()
+	callClass(147)
 }
 
 //export c148
 func c148(vm unsafe.Pointer) {
-	f := cMap[148]
-	if f == nil {
-		panic("function 148 not registered")
-	}
-	f()
+	callClass(148)
 }
 
 //export c149
 func c149(vm unsafe.Pointer) {
-	f := cMap[149]
-	if f == nil {
-		panic("function 149 not registered")
-	}
-	f()
+	callClass(149)
 }
 
 //export c150
 func c150(vm unsafe.Pointer) {
-	f := cMap[150]
-	if f == nil {
-		panic("function 150 not registered")
-	}
-	f()
+	callClass(150)
 }
 
 //export c151
 func c151(vm unsafe.Pointer) {
-	f := cMap[151]
-	if f == nil {
-		panic("function 151 not registered")
-	}
-	f()
+	callClass(151)
 }
 
 //export c152
 func c152(vm unsafe.Pointer) {
-	f := cMap[152]
-	if f == nil {
-		panic("function 152 not registered")
-	}
-	f()
+	callClass(152)
 }
 
 //export c153
 func c153(vm unsafe.Pointer) {
-	f := cMap[153]
-	if f == nil {
-		panic("function 153 not registered")
-	}
-	f()
+	callClass(153)
 }
 
 //export c154
 func c154(vm unsafe.Pointer) {
-	f := cMap[154]
-	if f == nil {
-		panic("function 154 not registered")
-	}
-	f()
+	callClass(154)
 }
 
 //export c155
 func c155(vm unsafe.Pointer) {
-	f := cMap[155]
-	if f == nil {
-		panic("function 155 not registered")
-	}
-	f()
+	callClass(155)
 }
 
 //export c156
 func c156(vm unsafe.Pointer) {
-	f := cMap[156]
-	if f == nil {
-		panic("function 156 not registered")
-	}
-	f()
+	callClass(156)
 }
 
 //export c157
 func c157(vm unsafe.Pointer) {
-	f := cMap[157]
-	if f == nil {
-		panic("function 157 not registered")
-	}
-	f()
+	callClass(157)
 }
 
 //export c158
 func c158(vm unsafe.Pointer) {
-	f := cMap[158]
-	if f == nil {
-		panic("function 158 not registered")
-	}
-	f()
+	callClass(158)
 }
 
 //export c159
 func c159(vm unsafe.Pointer) {
-	f := cMap[159]
-	if f == nil {
-		panic("function 159 not registered")
-	}
-	f()
+	callClass(159)
 }
 
 //export c160
 func c160(vm unsafe.Pointer) {
-	f := cMap[160]
-	if f == nil {
-		panic("function 160 not registered")
-	}
-	f()
+	callClass(160)
 }
 
 //export c161
 func c161(vm unsafe.Pointer) {
-	f := cMap[161]
-	if f == nil {
-		panic("function 161 not registered")
-	}
-	f()
+	callClass(161)
 }
 
 //export c162
 func c162(vm unsafe.Pointer) {
-	f := cMap[162]
-	if f == nil {
-		panic("function 162 not registered")
-	}
-	f()
+	callClass(162)
 }
 
 //export c163
 func c163(vm unsafe.Pointer) {
-	f := cMap[163]
-	if f == nil {
-		panic("function 163 not registered")
-	}
-	f()
+	callClass(163)
 }
 
 //export c164
 func c164(vm unsafe.Pointer) {
-	f := cMap[164]
-	if f == nil {
-		panic("function 164 not registered")
-	}
-	f()
+	callClass(164)
 }
 
 //export c165
 func c165(vm unsafe.Pointer) {
-	f := cMap[165]
-	if f == nil {
-		panic("function 165 not registered")
-	}
-	f()
+	callClass(165)
 }
 
 //export c166
 func c166(vm unsafe.Pointer) {
-	f := cMap[166]
-	if f == nil {
-		panic("function 166 not registered")
-	}
-	f()
+	callClass(166)
 }
 
 //export c167
 func c167(vm unsafe.Pointer) {
-	f := cMap[167]
-	if f == nil {
-		panic("function 167 not registered")
-	}
-	f()
+	callClass(167)
 }
 
 //export c168
 func c168(vm unsafe.Pointer) {
-	f := cMap[168]
-	if f == nil {
-		panic("function 168 not registered")
-	}
-	f()
+	callClass(168)
 }
 
 //export c169
 func c169(vm unsafe.Pointer) {
-	f := cMap[169]
-	if f == nil {
-		panic("function 169 not registered")
-	}
-	f()
+	callClass(169)
 }
 
 //export c170
 func c170(vm unsafe.Pointer) {
-	f := cMap[170]
-	if f == nil {
-		panic("function 170 not registered")
-	}
-	f()
+	callClass(170)
 }
 
 //export c171
 func c171(vm unsafe.Pointer) {
-	f := cMap[171]
-	if f == nil {
-		panic("function 171 not registered")
-	}
-	f()
+	callClass(171)
 }
 
 //export c172
 func c172(vm unsafe.Pointer) {
-	f := cMap[172]
-	if f == nil {
-		panic("function 172 not registered")
-	}
-	f()
+	callClass(172)
 }
 
 //export c173
 func c173(vm unsafe.Pointer) {
-	f := cMap[173]
-	if f == nil {
-		panic("function 173 not registered")
-	}
-	f()
+	callClass(173)
 }
 
 //export c174
 func c174(vm unsafe.Pointer) {
-	f := cMap[174]
-	if f == nil {
-		panic("function 174 not registered")
-	}
-	f()
+	callClass(174)
 }
 
 //export c175
 func c175(vm unsafe.Pointer) {
-	f := cMap[175]
-	if f == nil {
-		panic("function 175 not registered")
-	}
-	f()
+	callClass(175)
 }
 
 //export c176
 func c176(vm unsafe.Pointer) {
-	f := cMap[176]
-	if f == nil {
-		panic("function 176 not registered")
-	}
-	f()
+	callClass(176)
 }
 
 //export c177
 func c177(vm unsafe.Pointer) {
-	f := cMap[177]
-	if f == nil {
-		panic("function 177 not registered")
-	}
-	f()
+	callClass(177)
 }
 
 //export c178
 func c178(vm unsafe.Pointer) {
-	f := cMap[178]
-	if f == nil {
-		panic("function 178 not registered")
-	}
-	f()
+	callClass(178)
 }
 
 //export c179
 func c179(vm unsafe.Pointer) {
-	f := cMap[179]
-	if f == nil {
-		panic("function 179 not registered")
-	}
-	f()
+	callClass(179)
 }
 
 //export c180
 func c180(vm unsafe.Pointer) {
-	f := cMap[180]
-	if f == nil {
-		panic("function 180 not registered")
-	}
-	f()
+	callClass(180)
 }
 
 //export c181
 func c181(vm unsafe.Pointer) {
-	f := cMap[181]
-	if f == nil {
-		panic("function 181 not registered")
-	}
-	f()
+	callClass(181)
 }
 
 //export c182
 func c182(vm unsafe.Pointer) {
-	f := cMap[182]
-	if f == nil {
-		panic("function 182 not registered")
-	}
-	f()
+	callClass(182)
 }
 
 //export c183
 func c183(vm unsafe.Pointer) {
-	f := cMap[183]
-	if f == nil {
-		panic("function 183 not registered")
-	}
-	f()
+	callClass(183)
 }
 
 //export c184
 func c184(vm unsafe.Pointer) {
-	f := cMap[184]
-	if f == nil {
-		panic("function 184 not registered")
-	}
-	f()
+	callClass(184)
 }
 
 //export c185
 func c185(vm unsafe.Pointer) {
-	f := cMap[185]
-	if f == nil {
-		panic("function 185 not registered")
-	}
-	f()
+	callClass(185)
 }
 
 //export c186
 func c186(vm unsafe.Pointer) {
-	f := cMap[186]
-	if f == nil {
-		panic("function 186 not registered")
-	}
-	f()
+	callClass(186)
 }
 
 //export c187
 func c187(vm unsafe.Pointer) {
-	f := cMap[187]
-	if f == nil {
-		panic("function 187 not registered")
-	}
-	f()
+	callClass(187)
 }
 
 //export c188
 func c188(vm unsafe.Pointer) {
-	f := cMap[188]
-	if f == nil {
-		panic("function 188 not registered")
-	}
-	f()
+	callClass(188)
 }
 
 //export c189
 func c189(vm unsafe.Pointer) {
-	f := cMap[189]
-	if f == nil {
-		panic("function 189 not registered")
-	}
-	f()
+	callClass(189)
 }
 
 //export c190
 func c190(vm unsafe.Pointer) {
-	f := cMap[190]
-	if f == nil {
-		panic("function 190 not registered")
-	}
-	f()
+	callClass(190)
 }
 
 //export c191
 func c191(vm unsafe.Pointer) {
-	f := cMap[191]
-	if f == nil {
-		panic("function 191 not registered")
-	}
-	f()
+	callClass(191)
 }
 
 //export c192
 func c192(vm unsafe.Pointer) {
-	f := cMap[192]
-	if f == nil {
-		panic("function 192 not registered")
-	}
-	f()
+	callClass(192)
 }
 
 //export c193
 func c193(vm unsafe.Pointer) {
-	f := cMap[193]
-	if f == nil {
-		panic("function 193 not registered")
-	}
-	f()
+	callClass(193)
 }
 
 //export c194
 func c194(vm unsafe.Pointer) {
-	f := cMap[194]
-	if f == nil {
-		panic("function 194 not registered")
-	}
-	f()
+	callClass(194)
 }
 
 //export c195
 func c195(vm unsafe.Pointer) {
-	f := cMap[195]
-	if f == nil {
-		panic("function 195 not registered")
-	}
-	f()
+	callClass(195)
 }
 
 //export c196
 func c196(vm unsafe.Pointer) {
-	f := cMap[196]
-	if f == nil {
-		panic("function 196 not registered")
-	}
-	f()
+	callClass(196)
 }
 
 //export c197
 func c197(vm unsafe.Pointer) {
-	f := cMap[197]
-	if f == nil {
-		panic("function 197 not registered")
-	}
-	f()
+	callClass(197)
 }
 
 //export c198
 func c198(vm unsafe.Pointer) {
-	f := cMap[198]
-	if f == nil {
-		panic("function 198 not registered")
-	}
-	f()
+	callClass(198)
 }
 
 //export c199
 func c199(vm unsafe.Pointer) {
-	f := cMap[199]
-	if f == nil {
-		panic("function 199 not registered")
-	}
-	f()
+	callClass(199)
 }
 
 //export c200
 func c200(vm unsafe.Pointer) {
-	f := cMap[200]
-	if f == nil {
-		panic("function 200 not registered")
-	}
-	f()
+	callClass(200)
 }
 
 //export c201
 func c201(vm unsafe.Pointer) {
-	f := cMap[201]
-	if f == nil {
-		panic("function 201 not registered")
-	}
-	f()
+	callClass(201)
 }
 
 //export c202
 func c202(vm unsafe.Pointer) {
-	f := cMap[202]
-	if f == nil {
-		panic("function 202 not registered")
-	}
-	f()
+	callClass(202)
 }
 
 //export c203
 func c203(vm unsafe.Pointer) {
-	f := cMap[203]
-	if f == nil {
-		panic("function 203 not registered")
-	}
-	f()
+	callClass(203)
 }
 
 //export c204
 func c204(vm unsafe.Pointer) {
-	f := cMap[204]
-	if f == nil {
-		panic("function 204 not registered")
-	}
-	f()
+	callClass(204)
 }
 
 //export c205
 func c205(vm unsafe.Pointer) {
-	f := cMap[205]
-	if f == nil {
-		panic("function 205 not registered")
-	}
-	f()
+	callClass(205)
 }
 
 //export c206
 func c206(vm unsafe.Pointer) {
-	f := cMap[206]
-	if f == nil {
-		panic("function 206 not registered")
-	}
-	f()
+	callClass(206)
 }
 
 //export c207
 func c207(vm unsafe.Pointer) {
-	f := cMap[207]
-	if f == nil {
-		panic("function 207 not registered")
-	}
-	f()
+	callClass(207)
 }
 
 //export c208
 func c208(vm unsafe.Pointer) {
-	f := cMap[208]
-	if f == nil {
-		panic("function 208 not registered")
-	}
-	f()
+	callClass(208)
 }
 
 //export c209
 func c209(vm unsafe.Pointer) {
-	f := cMap[209]
-	if f == nil {
-		panic("function 209 not registered")
-	}
-	f()
+	callClass(209)
 }
 
 //export c210
 func c210(vm unsafe.Pointer) {
-	f := cMap[210]
-	if f == nil {
-		panic("function 210 not registered")
-	}
-	f()
+	callClass(210)
 }
 
 //export c211
 func c211(vm unsafe.Pointer) {
-	f := cMap[211]
-	if f == nil {
-		panic("function 211 not registered")
-	}
-	f()
+	callClass(211)
 }
 
 //export c212
 func c212(vm unsafe.Pointer) {
-	f := cMap[212]
-	if f == nil {
-		panic("function 212 not registered")
-	}
-	f()
+	callClass(212)
 }
 
 //export c213
 func c213(vm unsafe.Pointer) {
-	f := cMap[213]
-	if f == nil {
-		panic("function 213 not registered")
-	}
-	f()
+	callClass(213)
 }
 
 //export c214
 func c214(vm unsafe.Pointer) {
-	f := cMap[214]
-	if f == nil {
-		panic("function 214 not registered")
-	}
-	f()
+	callClass(214)
 }
 
 //export c215
 func c215(vm unsafe.Pointer) {
-	f := cMap[215]
-	if f == nil {
-		panic("function 215 not registered")
-	}
-	f()
+	callClass(215)
 }
 
 //export c216
 func c216(vm unsafe.Pointer) {
-	f := cMap[216]
-	if f == nil {
-		panic("function 216 not registered")
-	}
-	f()
+	callClass(216)
 }
 
 //export c217
 func c217(vm unsafe.Pointer) {
-	f := cMap[217]
-	if f == nil {
-		panic("function 217 not registered")
-	}
-	f()
+	callClass(217)
 }
 
 //export c218
 func c218(vm unsafe.Pointer) {
-	f := cMap[218]
-	if f == nil {
-		panic("function 218 not registered")
-	}
-	f()
+	callClass(218)
 }
 
 //export c219
 func c219(vm unsafe.Pointer) {
-	f := cMap[219]
-	if f == nil {
-		panic("function 219 not registered")
-	}
-	f()
+	callClass(219)
 }
 
 //export c220
 func c220(vm unsafe.Pointer) {
-	f := cMap[220]
-	if f == nil {
-		panic("function 220 not registered")
-	}
-	f()
+	callClass(220)
 }
 
 //export c221
 func c221(vm unsafe.Pointer) {
-	f := cMap[221]
-	if f == nil {
-		panic("function 221 not registered")
-	}
-	f()
+	callClass(221)
 }
 
 //export c222
 func c222(vm unsafe.Pointer) {
-	f := cMap[222]
-	if f == nil {
-		panic("function 222 not registered")
-	}
-	f()
+	callClass(222)
 }
 
 //export c223
 func c223(vm unsafe.Pointer) {
-	f := cMap[223]
-	if f == nil {
-		panic("function 223 not registered")
-	}
-	f()
+	callClass(223)
 }
 
 //export c224
 func c224(vm unsafe.Pointer) {
-	f := cMap[224]
-	if f == nil {
-		panic("function 224 not registered")
-	}
-	f()
+	callClass(224)
 }
 
 //export c225
 func c225(vm unsafe.Pointer) {
-	f := cMap[225]
-	if f == nil {
-		panic("function 225 not registered")
-	}
-	f()
+	callClass(225)
 }
 
 //export c226
 func c226(vm unsafe.Pointer) {
-	f := cMap[226]
-	if f == nil {
-		panic("function 226 not registered")
-	}
-	f()
+	callClass(226)
 }
 
 //export c227
 func c227(vm unsafe.Pointer) {
-	f := cMap[227]
-	if f == nil {
-		panic("function 227 not registered")
-	}
-	f()
+	callClass(227)
 }
 
 //export c228
 func c228(vm unsafe.Pointer) {
-	f := cMap[228]
-	if f == nil {
-		panic("function 228 not registered")
-	}
-	f()
+	callClass(228)
 }
 
 //export c229
 func c229(vm unsafe.Pointer) {
-	f := cMap[229]
-	if f == nil {
-		panic("function 229 not registered")
-	}
-	f()
+	callClass(229)
 }
 
 //export c230
 func c230(vm unsafe.Pointer) {
-	f := cMap[230]
-	if f == nil {
-		panic("function 230 not registered")
-	}
-	f()
+	callClass(230)
 }
 
 //export c231
 func c231(vm unsafe.Pointer) {
-	f := cMap[231]
-	if f == nil {
-		panic("function 231 not registered")
-	}
-	f()
+	callClass(231)
 }
 
 //export c232
 func c232(vm unsafe.Pointer) {
-	f := cMap[232]
-	if f == nil {
-		panic("function 232 not registered")
-	}
-	f()
+	callClass(232)
 }
 
 //export c233
 func c233(vm unsafe.Pointer) {
-	f := cMap[233]
-	if f == nil {
-		panic("function 233 not registered")
-	}
-	f()
+	callClass(233)
 }
 
 //export c234
 func c234(vm unsafe.Pointer) {
-	f := cMap[234]
-	if f == nil {
-		panic("function 234 not registered")
-	}
-	f()
+	callClass(234)
 }
 
 //export c235
 func c235(vm unsafe.Pointer) {
-	f := cMap[235]
-	if f == nil {
-		panic("function 235 not registered")
-	}
-	f()
+	callClass(235)
 }
 
 //export c236
 func c236(vm unsafe.Pointer) {
-	f := cMap[236]
-	if f == nil {
-		panic("function 236 not registered")
-	}
-	f()
+	callClass(236)
 }
 
 //export c237
 func c237(vm unsafe.Pointer) {
-	f := cMap[237]
-	if f == nil {
-		panic("function 237 not registered")
-	}
-	f()
+	callClass(237)
 }
 
 //export c238
 func c238(vm unsafe.Pointer) {
-	f := cMap[238]
-	if f == nil {
-		panic("function 238 not registered")
-	}
-	f()
+	callClass(238)
 }
 
 //export c239
 func c239(vm unsafe.Pointer) {
-	f := cMap[239]
-	if f == nil {
-		panic("function 239 not registered")
-	}
-	f()
+	callClass(239)
 }
 
 //export c240
 func c240(vm unsafe.Pointer) {
-	f := cMap[240]
-	if f == nil {
-		panic("function 240 not registered")
-	}
-	f()
+	callClass(240)
 }
 
 //export c241
 func c241(vm unsafe.Pointer) {
-	f := cMap[241]
-	if f == nil {
-		panic("function 241 not registered")
-	}
-	f()
+	callClass(241)
 }
 
 //export c242
 func c242(vm unsafe.Pointer) {
-	f := cMap[242]
-	if f == nil {
-		panic("function 242 not registered")
-	}
-	f()
+	callClass(242)
 }
 
 //export c243
 func c243(vm unsafe.Pointer) {
-	f := cMap[243]
-	if f == nil {
-		panic("function 243 not registered")
-	}
-	f()
+	callClass(243)
 }
 
 //export c244
 func c244(vm unsafe.Pointer) {
-	f := cMap[244]
-	if f == nil {
-		panic("function 244 not registered")
-	}
-	f()
+	callClass(244)
 }
 
 //export c245
 func c245(vm unsafe.Pointer) {
-	f := cMap[245]
-	if f == nil {
-		panic("function 245 not registered")
-	}
-	f()
+	callClass(245)
 }
 
 //export c246
 func c246(vm unsafe.Pointer) {
-	f := cMap[246]
-	if f == nil {
-		panic("function 246 not registered")
-	}
-	f()
+	callClass(246)
 }
 
 //export c247
 func c247(vm unsafe.Pointer) {
-	f := cMap[247]
-	if f == nil {
-		panic("function 247 not registered")
-	}
-	f()
+	callClass(247)
 }
 
 //export c248
 func c248(vm unsafe.Pointer) {
-	f := cMap[248]
-	if f == nil {
-		panic("function 248 not registered")
-	}
-	f()
+	callClass(248)
 }
 
 //export c249
 func c249(vm unsafe.Pointer) {
-	f := cMap[249]
-	if f == nil {
-		panic("function 249 not registered")
-	}
-	f()
+	callClass(249)
 }
 
 //export c250
 func c250(vm unsafe.Pointer) {
-	f := cMap[250]
-	if f == nil {
-		panic("function 250 not registered")
-	}
-	f()
+	callClass(250)
 }
 
 //export c251
 func c251(vm unsafe.Pointer) {
-	f := cMap[251]
-	if f == nil {
-		panic("function 251 not registered")
-	}
-	f()
+	callClass(251)
 }
 
 //export c252
 func c252(vm unsafe.Pointer) {
-	f := cMap[252]
-	if f == nil {
-		panic("function 252 not registered")
-	}
-	f()
+	callClass(252)
 }
 
 //export c253
 func c253(vm unsafe.Pointer) {
-	f := cMap[253]
-	if f == nil {
-		panic("function 253 not registered")
-	}
-	f()
+	callClass(253)
 }
 
 //export c254
 func c254(vm unsafe.Pointer) {
-	f := cMap[254]
-	if f == nil {
-		panic("function 254 not registered")
-	}
-	f()
+	callClass(254)
 }
 
 //export c255
 func c255(vm unsafe.Pointer) {
-	f := cMap[255]
+	callClass(255)
+}
+
+
+// Classes may be registered by one VM while another one is allocating them.
+func callClass(i int) {
+	cMapGuard.Lock()
+	f := cMap[i]
+	cMapGuard.Unlock()
+
 	if f == nil {
-		panic("function 255 not registered")
+		panic(fmt.Sprintf("function %d not registered", i))
 	}
 	f()
 }
 
-
 func registerClass(name string, f func()) (unsafe.Pointer, error) {
+	cMapGuard.Lock()
+	defer cMapGuard.Unlock()
+
 	if (cCounter+1) >= MAX_CLASS_REGISTRATIONS {
 		return nil, errors.New("maximum function registration reached")
 	}
 
-	cMapGuard.Lock()
-	defer cMapGuard.Unlock()
-
 	cMap[cCounter] = f
 	ptr := C.get_c(C.int(cCounter))
 	cCounter++
@@ -0,0 +1,122 @@
+package wrengo
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A source of time for the built-in modules, so scripts can run against a
+// [FakeClock] in tests.
+type Clock interface {
+	// Returns the current time.
+	Now() time.Time
+
+	// Waits for [d] to elapse and then sends the current time on the returned
+	// channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// The clock of the system, used when the configuration has no [Clock].
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// A clock that only moves when told to, for deterministic tests.
+//
+// A fake clock should be used by one VM only, since [VM.AdvanceClock] expects
+// every timer it fires to resume a fiber of that VM.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// Creates a fake clock starting at [now].
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := fakeTimer{deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t.c
+	}
+
+	// Timers with the same deadline fire in the order they were made.
+	i := sort.Search(len(c.timers), func(i int) bool {
+		return c.timers[i].deadline.After(t.deadline)
+	})
+	c.timers = append(c.timers, fakeTimer{})
+	copy(c.timers[i+1:], c.timers[i:])
+	c.timers[i] = t
+	return t.c
+}
+
+// Moves the clock forward by [d], firing every timer that expires.
+func (c *FakeClock) Advance(d time.Duration) {
+	end := c.Now().Add(d)
+	for c.fireNext(end) {
+	}
+
+	c.mu.Lock()
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Moves the clock to the deadline of the earliest timer and fires it, if that
+// is not after [end]. Reports whether a timer fired.
+func (c *FakeClock) fireNext(end time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.timers) == 0 || c.timers[0].deadline.After(end) {
+		return false
+	}
+
+	t := c.timers[0]
+	c.timers = c.timers[1:]
+	c.now = t.deadline
+	t.c <- c.now
+	return true
+}
+
+// Moves the [FakeClock] of the VM forward by [d]. Every fiber whose timer
+// expires is resumed before the next timer fires, in the order of their
+// deadlines, so tests are deterministic.
+//
+// Returns the error of the first resumed fiber that hits a runtime error.
+func (vm *VM) AdvanceClock(d time.Duration) error {
+	clock, ok := vm.clock.(*FakeClock)
+	if !ok {
+		return errors.New("wrengo: VM does not use a fake clock")
+	}
+
+	end := clock.Now().Add(d)
+	for clock.fireNext(end) {
+		if err := vm.complete(<-vm.loop.done); err != nil {
+			return err
+		}
+	}
+	clock.Advance(end.Sub(clock.Now()))
+	return nil
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/Terisback/wrengo"
 )
@@ -12,16 +16,38 @@ import (
 func main() {
 	var dir string
 
-	if len(os.Args) == 2 {
-		dir = os.Args[1]
+	if len(os.Args) >= 2 && os.Args[1] == "serve" {
+		serve(os.Args[2:])
+		return
+	}
+
+	var env listFlag
+	flag.Var(&env, "env", "let scripts read the environment variable `NAME`, can be repeated")
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) >= 1 {
+		dir = args[0]
 	}
 
 	// New configuration for VM
 	config := wrengo.NewConfiguration()
 
-	// Adding callbacks
+	// Adding callbacks, errors are rendered with their source
+	diagnostics := wrengo.NewDiagnostics(os.Stderr)
+	diagnostics.Color = isTerminal(os.Stderr)
 	config.WriteFunc = wrengo.CallbackWrite
-	config.ErrorFunc = wrengo.CallbackError
+	config.ErrorFunc = diagnostics.ErrorFunc
+
+	// Scripts can access files in the working directory
+	config.IO = &wrengo.IOConfig{Root: "."}
+
+	// Scripts see the arguments following their path, and only the
+	// environment variables allowed with -env
+	config.OS = &wrengo.OSConfig{Env: env}
+	if len(args) > 1 {
+		config.OS.Arguments = args[1:]
+	}
 
 	// Creating new VM
 	vm := wrengo.NewVM(config)
@@ -39,7 +65,12 @@ func main() {
 				fmt.Println("idk why it's doesn't work, but the error is", err)
 				os.Exit(1)
 			}
-			err = vm.Interpret(wrengo.DefaultModule, string(code))
+			err = run(&vm, string(code))
+			var exit *wrengo.ExitError
+			if errors.As(err, &exit) {
+				vm.FreeVM()
+				os.Exit(exit.Code)
+			}
 			if err != nil {
 				fmt.Println("Interpret says it don't like you, and also it says", err)
 				os.Exit(1)
@@ -57,9 +88,44 @@ func main() {
 	for {
 		fmt.Print("> ")
 		text, _ := reader.ReadString('\n')
-		err := vm.Interpret("main", text+"\n")
+		err := run(&vm, text+"\n")
+		var exit *wrengo.ExitError
+		if errors.As(err, &exit) {
+			vm.FreeVM()
+			os.Exit(exit.Code)
+		}
 		if err != nil {
 			fmt.Println(err)
 		}
 	}
 }
+
+// Interprets [source] in the main module, then resumes the fibers waiting for
+// asynchronous work, like timers and requests, until none is left.
+func run(vm *wrengo.VM, source string) error {
+	if err := vm.Interpret(wrengo.DefaultModule, source); err != nil {
+		return err
+	}
+	return vm.RunLoop(context.Background())
+}
+
+// A flag that can be repeated, collecting every value.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Reports whether [f] is attached to a terminal, so colours can be used.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Terisback/wrengo"
+	wrenhttp "github.com/Terisback/wrengo/http"
+)
+
+// Serves the scripts in a directory over HTTP, for local development:
+//
+//	wrengo serve dir/ [address]
+func serve(args []string) {
+	if len(args) == 0 {
+		fmt.Println("usage: wrengo serve dir/ [address]")
+		os.Exit(2)
+	}
+
+	dir, addr := args[0], "localhost:8080"
+	if len(args) > 1 {
+		addr = args[1]
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Println(dir, "is not a directory")
+		os.Exit(1)
+	}
+
+	// Errors are written as they come, since requests run concurrently
+	config := wrengo.NewConfiguration()
+	config.ErrorFunc = wrengo.CallbackError
+	config.IO = &wrengo.IOConfig{Root: dir}
+
+	handler := wrenhttp.NewHandler(wrenhttp.Config{
+		Scripts: os.DirFS(dir),
+		Pool:    wrengo.PoolConfig{Configuration: config},
+		Timeout: 30 * time.Second,
+	})
+
+	fmt.Printf("Serving %s on http://%s\n", dir, addr)
+	log.Fatal(http.ListenAndServe(addr, handler))
+}
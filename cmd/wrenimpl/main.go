@@ -0,0 +1,220 @@
+// Command wrenimpl generates the adapters letting [wrengo.Implement] return
+// interfaces implemented by Wren objects:
+//
+//	//go:generate go run github.com/Terisback/wrengo/cmd/wrenimpl -type Plugin
+//
+// The adapter of Plugin is written to plugin_wren.go, or plugin_wren_test.go
+// if the interface is declared in a test file, in the same directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	typeName := flag.String("type", "", "the interface to generate an adapter for")
+	output := flag.String("output", "", "the file to write, instead of <type>_wren.go")
+	flag.Parse()
+
+	if *typeName == "" {
+		fmt.Fprintln(os.Stderr, "wrenimpl: -type is required")
+		os.Exit(2)
+	}
+
+	source, file, err := generate(".", *typeName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wrenimpl:", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_wren.go"
+		if strings.HasSuffix(file, "_test.go") {
+			*output = strings.ToLower(*typeName) + "_wren_test.go"
+		}
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "wrenimpl:", err)
+		os.Exit(1)
+	}
+}
+
+// A method of the interface, as the adapter forwards it.
+type method struct {
+	name      string
+	signature string
+	params    []string
+	results   []string
+}
+
+// Returns the adapter of the interface called [typeName] declared in [dir],
+// and the file declaring it.
+func generate(dir, typeName string) ([]byte, string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, "", err
+		}
+		if iface := findInterface(f, typeName); iface != nil {
+			source, err := adapter(f, typeName, iface)
+			return source, name, err
+		}
+	}
+	return nil, "", fmt.Errorf("interface %s not found in %s", typeName, dir)
+}
+
+func findInterface(f *ast.File, typeName string) *ast.InterfaceType {
+	for _, decl := range f.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok && spec.Name.Name == typeName {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the source of the adapter of the interface [typeName] declared in
+// [f].
+func adapter(f *ast.File, typeName string, iface *ast.InterfaceType) ([]byte, error) {
+	var (
+		methods []method
+		names   = make(map[string]bool)
+		used    = make(map[string]bool)
+	)
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			return nil, fmt.Errorf("%s embeds %s, which is not supported", typeName, types.ExprString(field.Type))
+		}
+		names[field.Names[0].Name] = true
+
+		m, err := newMethod(field.Names[0].Name, field.Type.(*ast.FuncType))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, field.Names[0].Name, err)
+		}
+		methods = append(methods, m)
+
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					used[id.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	for _, m := range methods {
+		if names[m.name+"Fn"] {
+			return nil, fmt.Errorf("%s has both %s and %sFn methods", typeName, m.name, m.name)
+		}
+	}
+
+	// The package itself can't be imported by its own adapter.
+	qualifier := "wrengo."
+	imports := []string{strconv.Quote("github.com/Terisback/wrengo")}
+	if f.Name.Name == "wrengo" {
+		qualifier, imports = "", nil
+	}
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if used[name] {
+			if imp.Name != nil {
+				imports = append(imports, imp.Name.Name+" "+imp.Path.Value)
+			} else {
+				imports = append(imports, imp.Path.Value)
+			}
+		}
+	}
+	sort.Strings(imports)
+
+	adapterName := "wren" + string(unicode.ToUpper(rune(typeName[0]))) + typeName[1:]
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by wrenimpl; DO NOT EDIT.\n\npackage %s\n\n", f.Name.Name)
+	if len(imports) > 0 {
+		fmt.Fprintf(&b, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+
+	fmt.Fprintf(&b, "// Implements %s with the methods of a Wren object, see %sImplement.\n", typeName, qualifier)
+	fmt.Fprintf(&b, "type %s struct {\n", adapterName)
+	for _, m := range methods {
+		fmt.Fprintf(&b, "%sFn func(%s) (%s) `wren:%q`\n", m.name, strings.Join(m.params, ", "), strings.Join(m.results, ", "), m.signature)
+	}
+	b.WriteString("}\n\n")
+
+	for _, m := range methods {
+		var params, args []string
+		for i, p := range m.params {
+			params = append(params, fmt.Sprintf("p%d %s", i, p))
+			args = append(args, fmt.Sprintf("p%d", i))
+		}
+		ret := ""
+		if len(m.results) > 0 {
+			ret = "return "
+		}
+		fmt.Fprintf(&b, "func (w %s) %s(%s) (%s) {\n%sw.%sFn(%s)\n}\n\n",
+			adapterName, m.name, strings.Join(params, ", "), strings.Join(m.results, ", "), ret, m.name, strings.Join(args, ", "))
+	}
+
+	fmt.Fprintf(&b, "func init() {\n%sRegisterAdapter(func(w %s) %s { return w })\n}\n", qualifier, adapterName, typeName)
+	return format.Source(b.Bytes())
+}
+
+// Describes the method [name] of type [t], checking [wrengo.Implement] can
+// forward it.
+func newMethod(name string, t *ast.FuncType) (method, error) {
+	m := method{name: name}
+	for _, field := range t.Params.List {
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			return m, fmt.Errorf("variadic methods are not supported")
+		}
+		for range max(len(field.Names), 1) {
+			m.params = append(m.params, types.ExprString(field.Type))
+		}
+	}
+	if t.Results != nil {
+		for _, field := range t.Results.List {
+			for range max(len(field.Names), 1) {
+				m.results = append(m.results, types.ExprString(field.Type))
+			}
+		}
+	}
+	if len(m.results) > 2 || len(m.results) == 2 && m.results[1] != "error" {
+		return m, fmt.Errorf("methods must return at most a value and an error")
+	}
+
+	// The same names wrengo gives to fields without a tag.
+	m.signature = string(unicode.ToLower(rune(name[0]))) + name[1:]
+	if len(m.params) > 0 {
+		m.signature += "(" + strings.TrimSuffix(strings.Repeat("_,", len(m.params)), ",") + ")"
+	}
+	return m, nil
+}
@@ -0,0 +1,119 @@
+package wrengo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// The built-in "crypto" module, hashing strings of bytes:
+//
+//	import "crypto" for Hash
+//	import "encoding" for Hex
+//
+//	System.print(Hex.encode(Hash.sha256("payload")))
+//	System.print(Hex.encode(Hash.hmac("secret", "payload")))
+//
+// Hashes are strings of raw bytes. HMACs use SHA-256.
+var cryptoModule = &Module{
+	Name: "crypto",
+	Source: `
+class Hash {
+  foreign static sha256(data)
+  foreign static hmac(key, data)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Hash.sha256(_)": hashSHA256,
+		"static Hash.hmac(_,_)": hashHMAC,
+	},
+}
+
+// The built-in "encoding" module, converting strings of bytes to text and
+// back:
+//
+//	import "encoding" for Base64, Hex
+//
+//	System.print(Base64.encode("hello"))
+//	System.print(Hex.decode("68656c6c6f"))
+//
+// Decoding invalid text aborts the fiber.
+var encodingModule = &Module{
+	Name: "encoding",
+	Source: `
+class Base64 {
+  foreign static encode(data)
+  foreign static decode(text)
+}
+
+class Hex {
+  foreign static encode(data)
+  foreign static decode(text)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Base64.encode(_)": encodingEncode(base64.StdEncoding.EncodeToString),
+		"static Base64.decode(_)": encodingDecode(base64.StdEncoding.DecodeString),
+		"static Hex.encode(_)":    encodingEncode(hex.EncodeToString),
+		"static Hex.decode(_)":    encodingDecode(hex.DecodeString),
+	},
+}
+
+// Reads the bytes of the string in [slot], or aborts the fiber if there is
+// something else.
+func slotBytes(vm *VM, slot int, name string) ([]byte, bool) {
+	if vm.GetSlotType(slot) != WREN_TYPE_STRING {
+		vm.abortFiber(name + " must be a string.")
+		return nil, false
+	}
+	return vm.GetSlotBytes(slot, 0), true
+}
+
+func hashSHA256(vm *VM) {
+	data, ok := slotBytes(vm, 1, "Data")
+	if !ok {
+		return
+	}
+	sum := sha256.Sum256(data)
+	vm.SetSlotBytes(0, sum[:])
+}
+
+func hashHMAC(vm *VM) {
+	key, ok := slotBytes(vm, 1, "Key")
+	if !ok {
+		return
+	}
+	data, ok := slotBytes(vm, 2, "Data")
+	if !ok {
+		return
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	vm.SetSlotBytes(0, mac.Sum(nil))
+}
+
+func encodingEncode(encode func([]byte) string) func(*VM) {
+	return func(vm *VM) {
+		data, ok := slotBytes(vm, 1, "Data")
+		if !ok {
+			return
+		}
+		vm.SetSlotString(0, encode(data))
+	}
+}
+
+func encodingDecode(decode func(string) ([]byte, error)) func(*VM) {
+	return func(vm *VM) {
+		text, ok := slotBytes(vm, 1, "Text")
+		if !ok {
+			return
+		}
+		data, err := decode(string(text))
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		vm.SetSlotBytes(0, data)
+	}
+}
@@ -0,0 +1,51 @@
+package wrengo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrypto(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "crypto" for Hash
+		import "encoding" for Base64, Hex
+		System.print(Hex.encode(Hash.sha256("payload")))
+		System.print(Hex.encode(Hash.hmac("secret", "payload")))
+		System.print(Base64.encode("hello"))
+		System.print(Base64.decode("aGVsbG8="))
+
+		var binary = Hex.decode("00ff0010")
+		System.print([binary.bytes.count, Hex.encode(binary), Base64.decode(Base64.encode(binary)) == binary])
+	`))
+
+	sum := sha256.Sum256([]byte("payload"))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("payload"))
+	assert.Equal(t, hex.EncodeToString(sum[:])+"\n"+hex.EncodeToString(mac.Sum(nil))+"\n"+
+		"aGVsbG8=\nhello\n[4, 00ff0010, true]\n", out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Hex.decode("xyz")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Base64.decode("***")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Hash.sha256(1)`))
+}
+
+func TestSlotBytes(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	vm.EnsureSlots(1)
+	vm.SetSlotBytes(0, []byte{'a', 0, 'b'})
+	assert.Equal(t, []byte{'a', 0, 'b'}, vm.GetSlotBytes(0, 0))
+}
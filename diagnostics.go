@@ -0,0 +1,201 @@
+package wrengo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ANSI escape codes used when [Diagnostics] are coloured.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiBlue  = "\x1b[34m"
+	ansiGrey  = "\x1b[90m"
+)
+
+// Wren prefixes compile errors with the token they happened at, like
+// "Error at 'foo': Expected expression.".
+var compileErrorToken = regexp.MustCompile(`^Error at '(.*)': `)
+
+// Renders errors reported by Wren together with the source code they point at.
+//
+// The VM keeps the source of every module passed to [Interpret] or returned by
+// [LoadModuleFunc], so the offending line can be shown with [Context] lines
+// around it:
+//
+//	error: Error at '}': Expected expression.
+//	  --> main:2
+//	   |
+//	 1 | var x = [1, 2
+//	 2 | }
+//	   | ^
+//
+// Use [ErrorFunc] as the error callback of a configuration. The same
+// diagnostics may serve several VMs running concurrently, like the ones of a
+// [Pool]: every line is written at once, though the lines of errors happening
+// at the same time may interleave.
+type Diagnostics struct {
+	// Where rendered errors are written.
+	Writer io.Writer
+
+	// Number of source lines shown before and after the offending line of a
+	// compile or runtime error.
+	Context int
+
+	// Highlight the output with ANSI escape codes.
+	Color bool
+
+	mu sync.Mutex
+
+	// VMs whose runtime error was reported but not its innermost frame yet,
+	// since only that frame gets context lines.
+	inTrace map[*VM]bool
+}
+
+// Creates diagnostics writing into [w] with two lines of context and no
+// colour.
+func NewDiagnostics(w io.Writer) *Diagnostics {
+	return &Diagnostics{Writer: w, Context: 2}
+}
+
+// The error callback rendering errors of [vm].
+func (d *Diagnostics) ErrorFunc(vm *VM, errorType ErrorType, module string, line int, message string) {
+	var b bytes.Buffer
+	switch errorType {
+	case ERROR_COMPILE:
+		d.setInTrace(vm, false)
+		d.header(&b, message)
+		d.location(&b, module, line)
+		d.snippet(&b, vm.sources[module], line, d.Context, compileErrorColumn(vm.sources[module], line, message))
+	case ERROR_RUNTIME:
+		d.header(&b, message)
+		d.setInTrace(vm, true)
+	case ERROR_STACK_TRACE:
+		context := 0
+		if d.setInTrace(vm, false) {
+			context = d.Context
+		}
+		fmt.Fprintf(&b, "  %s %s\n", d.paint(ansiBlue, "at"), d.paint(ansiBold, message))
+		d.location(&b, module, line)
+		d.snippet(&b, vm.sources[module], line, context, nil)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.Writer.Write(b.Bytes())
+}
+
+// Records whether [vm] is about to report the innermost frame of a runtime
+// error, returning whether it was.
+func (d *Diagnostics) setInTrace(vm *VM, inTrace bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	was := d.inTrace[vm]
+	if inTrace {
+		if d.inTrace == nil {
+			d.inTrace = make(map[*VM]bool)
+		}
+		d.inTrace[vm] = true
+	} else {
+		delete(d.inTrace, vm)
+	}
+	return was
+}
+
+func (d *Diagnostics) paint(code, text string) string {
+	if !d.Color {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+func (d *Diagnostics) header(w io.Writer, message string) {
+	fmt.Fprintf(w, "%s %s\n", d.paint(ansiBold+ansiRed, "error:"), d.paint(ansiBold, message))
+}
+
+func (d *Diagnostics) location(w io.Writer, module string, line int) {
+	fmt.Fprintf(w, "  %s %s:%d\n", d.paint(ansiBlue, "-->"), module, line)
+}
+
+// Prints [line] of [source] with [context] lines around it, underlining the
+// [column] range of the offending line or the whole line when it is nil.
+func (d *Diagnostics) snippet(w io.Writer, source string, line, context int, column []int) {
+	lines := strings.Split(source, "\n")
+	if source == "" || line < 1 || line > len(lines) {
+		return
+	}
+
+	first, last := line-context, line+context
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	width := len(fmt.Sprint(last))
+	gutter := func(n string) string {
+		return d.paint(ansiBlue, fmt.Sprintf(" %*s |", width, n))
+	}
+
+	fmt.Fprintln(w, gutter(""))
+	for n := first; n <= last; n++ {
+		text := strings.TrimRight(lines[n-1], "\r")
+		if n != line {
+			fmt.Fprintln(w, gutter(fmt.Sprint(n)), d.paint(ansiGrey, text))
+			continue
+		}
+
+		fmt.Fprintln(w, gutter(fmt.Sprint(n)), text)
+
+		var start, end int
+		if column != nil {
+			start, end = column[0], column[1]
+		} else {
+			start = len(text) - len(strings.TrimLeft(text, " \t"))
+			end = len(strings.TrimRight(text, " \t"))
+		}
+		if start > len(text) {
+			start = len(text)
+		}
+		if end <= start {
+			end = start + 1
+		}
+
+		// Keep tabs, so the carets line up with the source above them.
+		indent := strings.Map(func(r rune) rune {
+			if r == '\t' {
+				return r
+			}
+			return ' '
+		}, text[:start])
+		carets := strings.Repeat("^", end-start)
+		fmt.Fprintln(w, gutter(""), indent+d.paint(ansiBold+ansiRed, carets))
+	}
+}
+
+// Finds the column range of the token a compile error happened at, or returns
+// nil if it cannot be found on the line.
+func compileErrorColumn(source string, line int, message string) []int {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return nil
+	}
+
+	match := compileErrorToken.FindStringSubmatch(message)
+	if match == nil || match[1] == "" {
+		return nil
+	}
+
+	start := strings.Index(lines[line-1], match[1])
+	if start < 0 {
+		return nil
+	}
+	return []int{start, start + len(match[1])}
+}
@@ -0,0 +1,54 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticsCompileError(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := NewConfiguration()
+	config.ErrorFunc = NewDiagnostics(&buf).ErrorFunc
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.Error(t, vm.Interpret(DefaultModule, "var a = 1\nvar b = )\nvar c = 3"))
+	assert.Equal(t, ""+
+		"error: Error at ')': Expected expression.\n"+
+		"  --> main:2\n"+
+		"   |\n"+
+		" 1 | var a = 1\n"+
+		" 2 | var b = )\n"+
+		"   |         ^\n"+
+		" 3 | var c = 3\n", buf.String())
+}
+
+func TestDiagnosticsStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+
+	d := NewDiagnostics(&buf)
+	d.Context = 0
+	config := NewConfiguration()
+	config.ErrorFunc = d.ErrorFunc
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.Error(t, vm.Interpret(DefaultModule, "class A {\n  static boom() {\n    Fiber.abort(\"boom\")\n  }\n}\nA.boom()"))
+
+	out := buf.String()
+	assert.Contains(t, out, "error: boom\n")
+	assert.Contains(t, out, "  --> main:3\n   |\n 3 |     Fiber.abort(\"boom\")\n   |     ^^^^^^^^^^^^^^^^^^^\n")
+	assert.Contains(t, out, "  --> main:6\n   |\n 6 | A.boom()\n   | ^^^^^^^^\n")
+}
+
+func TestDiagnosticsColor(t *testing.T) {
+	var buf bytes.Buffer
+
+	d := NewDiagnostics(&buf)
+	d.Color = true
+	d.ErrorFunc(&VM{sources: map[string]string{}}, ERROR_COMPILE, "main", 1, "oops")
+	assert.Contains(t, buf.String(), ansiRed)
+}
@@ -21,12 +21,26 @@ func (i InterpretResult) Error() error {
 	case RESULT_COMPILE_ERROR:
 		return errors.New("COMPILE_ERROR")
 	case RESULT_RUNTIME_ERROR:
-		return errors.New("RUNTIME_ERROR")
+		return &RuntimeError{}
 	default:
 		panic("unreachable")
 	}
 }
 
+// Returned when a script hits a runtime error, like a fiber being aborted.
+type RuntimeError struct {
+	// The error the fiber was aborted with, converted to a string. It is empty
+	// when only reported through the error callback.
+	Message string
+}
+
+func (e *RuntimeError) Error() string {
+	if e.Message == "" {
+		return "RUNTIME_ERROR"
+	}
+	return "RUNTIME_ERROR: " + e.Message
+}
+
 type ErrorType int
 
 const (
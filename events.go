@@ -0,0 +1,98 @@
+package wrengo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The built-in "events" module, letting scripts subscribe to the events the
+// host emits with [VM.Emit]:
+//
+//	import "events" for Events
+//
+//	Events.on("player joined") {|player|
+//	  System.print("Welcome, %(player["name"])!")
+//	}
+//
+// Handlers are called in the order they subscribed, each with its own copy of
+// the payload. A handler hitting a runtime error doesn't stop the others.
+var eventsModule = &Module{
+	Name: "events",
+	Source: `
+import "wrengo/value" for Value
+
+class Events {
+  static on(name, handler) {
+    if (!(name is String)) Fiber.abort("Event name must be a string.")
+    if (!(handler is Fn)) Fiber.abort("Handler must be a function.")
+    on_(name, handler)
+    return handler
+  }
+
+  static emit_(handler, payload) {
+    payload = Value.decode(payload)
+    var fiber = Fiber.new {
+      if (handler.arity == 0) {
+        handler.call()
+      } else {
+        handler.call(payload)
+      }
+    }
+    fiber.try()
+    return fiber.error == null ? null : fiber.error.toString
+  }
+
+  foreign static on_(name, handler)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Events.on_(_,_)": eventsOn,
+	},
+}
+
+func eventsOn(vm *VM) {
+	name := vm.GetSlotString(1)
+	vm.events[name] = append(vm.events[name], vm.GetSlotHandle(2))
+	vm.SetSlotNull(0)
+}
+
+// Calls every handler scripts subscribed to [name] with `Events.on(_,_)`,
+// passing [payload] converted as by [SetSlotValue], except that maps are
+// supported too.
+//
+// Every handler is called even if some hit a runtime error. Their errors are
+// returned joined. If the payload can't be converted, no handler is called.
+//
+// It must not be called from a foreign method.
+func (vm *VM) Emit(name string, payload interface{}) error {
+	// Handlers subscribing while the event is emitted are called next time.
+	handlers := append([]Handle(nil), vm.events[name]...)
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	// Converted once, so no handler is called with a payload that can't be.
+	vm.EnsureSlots(3)
+	if err := vm.setSlotEncoded(2, payload); err != nil {
+		return err
+	}
+	encoded := vm.GetSlotHandle(2)
+	defer encoded.Release()
+
+	emit := vm.callHandle("emit_(_,_)")
+	var errs []error
+	for _, handler := range handlers {
+		vm.EnsureSlots(3)
+		vm.GetVariable(eventsModule.Name, "Events", 0)
+		vm.SetSlotHandle(1, handler)
+		vm.SetSlotHandle(2, encoded)
+		if err := emit.Call(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if vm.GetSlotType(0) == WREN_TYPE_STRING {
+			errs = append(errs, fmt.Errorf("wrengo: handler of event %q: %s", name, vm.GetSlotString(0)))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,40 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmit(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Emit("player joined", nil))
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "events" for Events
+		Events.on("player joined") {|player|
+			player["greeted"] = true
+			System.print("Welcome, %(player["name"])!")
+		}
+		Events.on("player joined") {|player| Fiber.abort("Full.") }
+		Events.on("player joined") {|player| System.print(player.count) }
+		Events.on("tick") { System.print("tick") }
+	`))
+
+	err := vm.Emit("player joined", map[string]interface{}{"name": "wren"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Full.")
+	}
+	assert.NoError(t, vm.Emit("tick", nil))
+	assert.NoError(t, vm.Emit("unknown", nil))
+	assert.Error(t, vm.Emit("tick", make(chan int)))
+	assert.Equal(t, "Welcome, wren!\n1\ntick\n", out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Events.on(1) {}`))
+}
@@ -0,0 +1,95 @@
+package wrengo
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Returned by [Executor.Do] once the executor is closed.
+var ErrExecutorClosed = errors.New("wrengo: executor closed")
+
+// Runs everything done with a VM on one dedicated goroutine, so the VM can be
+// shared by any number of goroutines.
+//
+// Work passed to [Do] runs one piece at a time, in the order it arrives.
+type Executor struct {
+	vm    *VM
+	calls chan func()
+	quit  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// Creates an executor taking ownership of [vm]. If [lockOSThread] is set, the
+// goroutine of the executor is locked to its OS thread, for hosts whose
+// foreign methods call thread-bound libraries.
+func NewExecutor(vm *VM, lockOSThread bool) *Executor {
+	e := &Executor{
+		vm:    vm,
+		calls: make(chan func()),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go e.run(lockOSThread)
+	return e
+}
+
+func (e *Executor) run(lockOSThread bool) {
+	if lockOSThread {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+	defer close(e.done)
+
+	for {
+		select {
+		case call := <-e.calls:
+			call()
+		case <-e.quit:
+			e.vm.FreeVM()
+			return
+		}
+	}
+}
+
+// Runs [f] with the VM on the goroutine of the executor and waits for it to
+// return. A panic in [f] is passed on to the caller.
+//
+// The VM must not be kept after [f] returns, and [Do] must not be called from
+// inside [f].
+func (e *Executor) Do(f func(*VM) error) error {
+	var (
+		err       error
+		recovered interface{}
+		done      = make(chan struct{})
+	)
+
+	call := func() {
+		defer close(done)
+		defer func() {
+			recovered = recover()
+		}()
+		err = f(e.vm)
+	}
+
+	select {
+	case e.calls <- call:
+	case <-e.quit:
+		return ErrExecutorClosed
+	}
+	<-done
+
+	if recovered != nil {
+		panic(recovered)
+	}
+	return err
+}
+
+// Stops the executor once the work in progress is done, and frees the VM.
+func (e *Executor) Close() {
+	e.once.Do(func() {
+		close(e.quit)
+	})
+	<-e.done
+}
@@ -0,0 +1,91 @@
+package wrengo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor(t *testing.T) {
+	config := NewConfiguration()
+	config.DetectConcurrentUse = true
+	vm := NewVM(config)
+	e := NewExecutor(&vm, true)
+
+	assert.NoError(t, e.Do(func(vm *VM) error {
+		return vm.Interpret(DefaultModule, `
+			class Counter {
+				static count { __count }
+				static increment() { __count = (__count || 0) + 1 }
+			}
+		`)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, e.Do(func(vm *VM) error {
+				return vm.Interpret(DefaultModule, `Counter.increment()`)
+			}))
+		}()
+	}
+	wg.Wait()
+
+	var count float64
+	assert.NoError(t, e.Do(func(vm *VM) error {
+		vm.EnsureSlots(1)
+		vm.GetVariable(DefaultModule, "Counter", 0)
+		h := vm.NewCallHandle("count")
+		defer h.Release()
+		if err := h.Call(); err != nil {
+			return err
+		}
+		count = vm.GetSlotDouble(0)
+		return nil
+	}))
+	assert.Equal(t, float64(16), count)
+
+	boom := errors.New("boom")
+	assert.Equal(t, boom, e.Do(func(vm *VM) error { return boom }))
+	assert.Panics(t, func() {
+		e.Do(func(vm *VM) error { panic("boom") })
+	})
+
+	e.Close()
+	assert.Equal(t, ErrExecutorClosed, e.Do(func(vm *VM) error { return nil }))
+}
+
+func TestDetectConcurrentUse(t *testing.T) {
+	config := NewConfiguration()
+	config.DetectConcurrentUse = true
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	inside, release := make(chan struct{}), make(chan struct{})
+	assert.NoError(t, vm.BindForeignMethod("Block", true, "wait()", func(vm *VM) {
+		vm.SetSlotNull(0)
+		close(inside)
+		<-release
+	}))
+
+	done := make(chan error)
+	go func() {
+		done <- vm.Interpret(DefaultModule, `
+			class Block {
+				foreign static wait()
+			}
+			Block.wait()
+		`)
+	}()
+
+	<-inside
+	assert.Panics(t, func() { vm.EnsureSlots(1) })
+	close(release)
+	assert.NoError(t, <-done)
+
+	assert.NotPanics(t, func() { vm.EnsureSlots(1) })
+}
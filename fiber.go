@@ -0,0 +1,176 @@
+package wrengo
+
+import (
+	"errors"
+)
+
+// What a [Fiber] did the last time it was resumed.
+type FiberState int
+
+const (
+	// The fiber has not run yet.
+	FIBER_NEW FiberState = iota
+
+	// The fiber called `Fiber.yield` and can be resumed again.
+	FIBER_YIELDED
+
+	// The fiber returned from its function.
+	FIBER_FINISHED
+
+	// The fiber was aborted by a runtime error.
+	FIBER_ERRORED
+)
+
+func (i FiberState) String() string {
+	return [...]string{"FIBER_NEW", "FIBER_YIELDED", "FIBER_FINISHED", "FIBER_ERRORED"}[i]
+}
+
+// The internal "wrengo/fiber" module, which resumes fibers on behalf of Go.
+//
+// Wren has no way to pass a value to a fiber and catch its errors at once, so
+// the fiber is called from a runner fiber that is tried instead. Functions
+// taking a parameter are wrapped, since the value a fiber is started with
+// isn't passed to its function.
+var fiberModule = &Module{
+	Name: "wrengo/fiber",
+	Source: `
+class Fibers {
+  static new(fn) {
+    if (fn.arity == 0) return Fiber.new(fn)
+    return Fiber.new { fn.call(__value) }
+  }
+
+  static resume(fiber, value) {
+    __value = value
+    var result
+    var runner = Fiber.new { result = fiber.call(value) }
+    runner.try()
+
+    // Errors may be any value, so they are converted for Go.
+    if (fiber.error != null) return [3, "%(fiber.error)"]
+    if (runner.error != null) return [3, "%(runner.error)"]
+    if (fiber.isDone) return [2, result]
+    return [1, result]
+  }
+}
+`,
+}
+
+// A Wren fiber driven from Go, like a coroutine a game loop resumes every frame
+// or a generator consumed as an iterator.
+//
+// The fiber must not be resumed from inside a foreign method, and must be
+// released with [Release] when no longer needed.
+type Fiber struct {
+	vm     *VM
+	handle Handle
+	state  FiberState
+	value  interface{}
+	err    error
+}
+
+// Creates a fiber running the function held by [fn]. If the function takes a
+// parameter, it receives the value the fiber is first resumed with.
+//
+// The handle to the function is not released.
+func (vm *VM) NewFiber(fn Handle) (*Fiber, error) {
+	if err := vm.require(fiberModule); err != nil {
+		return nil, err
+	}
+
+	vm.EnsureSlots(2)
+	vm.GetVariable(fiberModule.Name, "Fibers", 0)
+	vm.SetSlotHandle(1, fn)
+	if err := vm.callHandle("new(_)").Call(); err != nil {
+		return nil, err
+	}
+
+	return &Fiber{vm: vm, handle: vm.GetSlotHandle(0)}, nil
+}
+
+// Runs the fiber until it yields or returns, passing [value] as the result of
+// the `Fiber.yield` it is suspended in.
+//
+// Returns the value the fiber yielded or returned, converted as by
+// [GetSlotValue]. If the fiber hits a runtime error, it is returned and the
+// fiber can't be resumed anymore.
+func (f *Fiber) Resume(value interface{}) (interface{}, error) {
+	switch f.state {
+	case FIBER_FINISHED:
+		return nil, errors.New("wrengo: cannot resume a finished fiber")
+	case FIBER_ERRORED:
+		return nil, f.err
+	}
+
+	vm := f.vm
+	vm.EnsureSlots(3)
+	vm.GetVariable(fiberModule.Name, "Fibers", 0)
+	vm.SetSlotHandle(1, f.handle)
+	if err := vm.SetSlotValue(2, value); err != nil {
+		return nil, err
+	}
+	if err := vm.callHandle("resume(_,_)").Call(); err != nil {
+		return nil, err
+	}
+
+	vm.EnsureSlots(2)
+	vm.GetListElement(0, 0, 1)
+	f.state = FiberState(vm.GetSlotDouble(1))
+	vm.GetListElement(0, 1, 1)
+
+	if f.state == FIBER_ERRORED {
+		f.err = &RuntimeError{Message: vm.GetSlotString(1)}
+		f.value = nil
+		return nil, f.err
+	}
+
+	f.value, f.err = vm.GetSlotValue(1)
+	return f.value, f.err
+}
+
+// Returns what the fiber did the last time it was resumed.
+func (f *Fiber) State() FiberState {
+	return f.state
+}
+
+// Reports whether the fiber finished or errored, so it can't be resumed.
+func (f *Fiber) IsDone() bool {
+	return f.state == FIBER_FINISHED || f.state == FIBER_ERRORED
+}
+
+// Resumes the fiber with null and reports whether it yielded a value, which
+// is then returned by [Value]. Lets a fiber be used as a generator:
+//
+//	for fiber.Next() {
+//		fmt.Println(fiber.Value())
+//	}
+//	if err := fiber.Err(); err != nil {
+//		...
+//	}
+//
+// The value the fiber finally returns is not produced.
+func (f *Fiber) Next() bool {
+	if f.IsDone() {
+		return false
+	}
+
+	_, err := f.Resume(nil)
+	return err == nil && f.state == FIBER_YIELDED
+}
+
+// Returns the value the fiber yielded or returned the last time it was
+// resumed.
+func (f *Fiber) Value() interface{} {
+	return f.value
+}
+
+// Returns the error of the fiber, if it hit a runtime error or the value it
+// yielded couldn't be converted.
+func (f *Fiber) Err() error {
+	return f.err
+}
+
+// Releases the fiber. After calling this, it can no longer be used.
+func (f *Fiber) Release() {
+	f.handle.Release()
+}
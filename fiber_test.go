@@ -0,0 +1,109 @@
+package wrengo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getFn(t *testing.T, vm *VM, source string) Handle {
+	assert.NoError(t, vm.Interpret(DefaultModule, "var fn = "+source))
+	vm.EnsureSlots(1)
+	vm.GetVariable(DefaultModule, "fn", 0)
+	return vm.GetSlotHandle(0)
+}
+
+func TestFiberResume(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new { |frame|
+		while (true) {
+			frame = Fiber.yield("frame %(frame)")
+			if (frame > 2) return "done"
+		}
+	}`)
+	defer fn.Release()
+
+	fiber, err := vm.NewFiber(fn)
+	assert.NoError(t, err)
+	defer fiber.Release()
+	assert.Equal(t, FIBER_NEW, fiber.State())
+
+	for frame := 1; frame <= 2; frame++ {
+		v, err := fiber.Resume(frame)
+		assert.NoError(t, err)
+		assert.Equal(t, "frame "+string(rune('0'+frame)), v)
+		assert.Equal(t, FIBER_YIELDED, fiber.State())
+	}
+
+	v, err := fiber.Resume(3)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", v)
+	assert.Equal(t, FIBER_FINISHED, fiber.State())
+	assert.True(t, fiber.IsDone())
+
+	_, err = fiber.Resume(nil)
+	assert.Error(t, err)
+}
+
+func TestFiberGenerator(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new {
+		for (i in 1..3) Fiber.yield(i * i)
+	}`)
+	defer fn.Release()
+
+	fiber, err := vm.NewFiber(fn)
+	assert.NoError(t, err)
+	defer fiber.Release()
+
+	var squares []interface{}
+	for fiber.Next() {
+		squares = append(squares, fiber.Value())
+	}
+	assert.NoError(t, fiber.Err())
+	assert.Equal(t, []interface{}{1.0, 4.0, 9.0}, squares)
+}
+
+func TestFiberError(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new {
+		Fiber.yield(1)
+		Fiber.abort("boom")
+	}`)
+	defer fn.Release()
+
+	fiber, err := vm.NewFiber(fn)
+	assert.NoError(t, err)
+	defer fiber.Release()
+
+	assert.True(t, fiber.Next())
+	assert.False(t, fiber.Next())
+	assert.Equal(t, FIBER_ERRORED, fiber.State())
+	assert.EqualError(t, fiber.Err(), "RUNTIME_ERROR: boom")
+
+	var runtimeErr *RuntimeError
+	assert.True(t, errors.As(fiber.Err(), &runtimeErr))
+	assert.Equal(t, "boom", runtimeErr.Message)
+}
+
+func TestFiberErrorValue(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new { Fiber.abort(42) }`)
+	defer fn.Release()
+
+	fiber, err := vm.NewFiber(fn)
+	assert.NoError(t, err)
+	defer fiber.Release()
+
+	_, err = fiber.Resume(nil)
+	assert.EqualError(t, err, "RUNTIME_ERROR: 42")
+}
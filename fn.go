@@ -0,0 +1,132 @@
+package wrengo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A Wren function kept by Go, like a callback a script registers with a
+// foreign method. Since [GetSlotFn] can't tell functions from other objects,
+// the method checks it was given one in Wren first:
+//
+//	class Callbacks {
+//	  static register(fn) {
+//	    if (!(fn is Fn)) Fiber.abort("Callback must be a function.")
+//	    register_(fn)
+//	  }
+//	  foreign static register_(fn)
+//	}
+//
+//	func register(vm *wrengo.VM) {
+//	  fn, err := vm.GetSlotFn(1)
+//	  if err != nil {
+//	    vm.SetSlotString(0, err.Error())
+//	    vm.AbortFiber(0)
+//	    return
+//	  }
+//	  handlers = append(handlers, fn)
+//	}
+//
+//	// Later, outside of foreign methods.
+//	result, err := handlers[0].Call(21)
+//
+// Functions still held when their VM is freed are released with it. Like the
+// VM, a function must only be called and released by the goroutine using the
+// VM at the time.
+type Fn struct {
+	vm     *VM
+	handle Handle
+
+	// The number of parameters of the function, once asked.
+	arity    int
+	hasArity bool
+}
+
+// Returns the function in [slot], which is kept until it is released with
+// [Release] or the VM is freed.
+//
+// Unlike calling it, this may be done from a foreign method. Only values which
+// can't be functions are rejected: other objects are only found out when the
+// function is called, so they should be rejected in Wren beforehand.
+func (vm *VM) GetSlotFn(slot int) (*Fn, error) {
+	defer vm.enter()()
+	if t := vm.GetSlotType(slot); t != WREN_TYPE_UNKNOWN {
+		return nil, fmt.Errorf("wrengo: cannot use %s as a function", t)
+	}
+
+	fn := &Fn{vm: vm, handle: vm.GetSlotHandle(slot)}
+	vm.fns[fn] = struct{}{}
+	return fn, nil
+}
+
+var errReleasedFn = errors.New("wrengo: function released")
+
+// Returns the number of parameters of the function.
+//
+// It must not be called from a foreign method.
+func (f *Fn) Arity() (int, error) {
+	if !f.held() {
+		return 0, errReleasedFn
+	}
+	if f.hasArity {
+		return f.arity, nil
+	}
+
+	arity, err := f.vm.callMethod(f.handle, "arity", nil, false)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := arity.(float64)
+	if !ok {
+		return 0, fmt.Errorf("wrengo: arity of function is %v", arity)
+	}
+
+	f.arity, f.hasArity = int(n), true
+	return f.arity, nil
+}
+
+// Calls the function with [args], which must be as many as its parameters.
+//
+// Arguments are converted as by [SetSlotValue], except that maps are
+// supported too. The result is converted as by [GetSlotValue], with maps
+// becoming map[interface{}]interface{}. Runtime errors of the function are
+// returned.
+//
+// It must not be called from a foreign method.
+func (f *Fn) Call(args ...interface{}) (interface{}, error) {
+	arity, err := f.Arity()
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != arity {
+		return nil, fmt.Errorf("wrengo: function expects %d arguments, got %d", arity, len(args))
+	}
+
+	signature := "call()"
+	if arity > 0 {
+		signature = "call(" + strings.TrimSuffix(strings.Repeat("_,", arity), ",") + ")"
+	}
+	return f.vm.callMethod(f.handle, signature, args, false)
+}
+
+// Returns the handle to the function, valid until it is released.
+func (f *Fn) Handle() Handle {
+	return f.handle
+}
+
+// Reports whether the function wasn't released yet, by itself or with its VM.
+func (f *Fn) held() bool {
+	_, ok := f.vm.fns[f]
+	return ok
+}
+
+// Releases the function. Releasing it again does nothing.
+func (f *Fn) Release() {
+	defer f.vm.enter()()
+	if !f.held() {
+		return
+	}
+	delete(f.vm.fns, f)
+	f.handle.Release()
+}
@@ -0,0 +1,63 @@
+package wrengo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFn(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+
+	var fns []*Fn
+	assert.NoError(t, vm.BindForeignMethod("Callbacks", true, "register_(_)", func(vm *VM) {
+		fn, err := vm.GetSlotFn(1)
+		if err != nil {
+			vm.SetSlotString(0, err.Error())
+			vm.AbortFiber(0)
+			return
+		}
+		fns = append(fns, fn)
+	}))
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		class Callbacks {
+			static register(fn) {
+				if (!(fn is Fn)) Fiber.abort("Callback must be a function.")
+				register_(fn)
+			}
+			foreign static register_(fn)
+		}
+		Callbacks.register {|x| x * 2 }
+		Callbacks.register {|a, b| {"sum": a + b} }
+		Callbacks.register { Fiber.abort("Failed.") }
+	`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Callbacks.register(1)`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Callbacks.register([1])`))
+	assert.Len(t, fns, 3)
+
+	arity, err := fns[1].Arity()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, arity)
+
+	result, err := fns[0].Call(21)
+	assert.NoError(t, err)
+	assert.Equal(t, 42.0, result)
+
+	result, err = fns[1].Call(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"sum": 3.0}, result)
+
+	_, err = fns[1].Call(1)
+	assert.Error(t, err)
+	_, err = fns[2].Call()
+	assert.Error(t, err)
+
+	fns[0].Release()
+	fns[0].Release()
+	_, err = fns[0].Call(1)
+	assert.Error(t, err)
+
+	// The others are released with the VM.
+	vm.FreeVM()
+	assert.Empty(t, vm.fns)
+}
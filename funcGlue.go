@@ -524,6 +524,7 @@ static inline void* get_f(int i) {
 import "C"
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -539,2317 +540,1305 @@ var (
 
 //export f0
 func f0(vm *C.WrenVM) {
-	f := fMap[0]
-	if f == nil {
-		panic("function 0 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(0, vm)
 }
 
 //export f1
 func f1(vm *C.WrenVM) {
-	f := fMap[1]
-	if f == nil {
-		panic("function 1 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(1, vm)
 }
 
 //export f2
 func f2(vm *C.WrenVM) {
-	f := fMap[2]
-	if f == nil {
-		panic("function 2 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(2, vm)
 }
 
 //export f3
 func f3(vm *C.WrenVM) {
-	f := fMap[3]
-	if f == nil {
-		panic("function 3 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(3, vm)
 }
 
 //export f4
 func f4(vm *C.WrenVM) {
-	f := fMap[4]
-	if f == nil {
-		panic("function 4 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(4, vm)
 }
 
 //export f5
 func f5(vm *C.WrenVM) {
-	f := fMap[5]
-	if f == nil {
-		panic("function 5 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(5, vm)
 }
 
 //export f6
 func f6(vm *C.WrenVM) {
-	f := fMap[6]
-	if f == nil {
-		panic("function 6 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(6, vm)
 }
 
 //export f7
 func f7(vm *C.WrenVM) {
-	f := fMap[7]
-	if f == nil {
-		panic("function 7 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(7, vm)
 }
 
 //export f8
 func f8(vm *C.WrenVM) {
-	f := fMap[8]
-	if f == nil {
-		panic("function 8 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(8, vm)
 }
 
 //export f9
 func f9(vm *C.WrenVM) {
-	f := fMap[9]
-	if f == nil {
-		panic("function 9 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(9, vm)
 }
 
 //export f10
 func f10(vm *C.WrenVM) {
-	f := fMap[10]
-	if f == nil {
-		panic("function 10 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(10, vm)
 }
 
 //export f11
 func f11(vm *C.WrenVM) {
-	f := fMap[11]
-	if f == nil {
-		panic("function 11 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(11, vm)
 }
 
 //export f12
 func f12(vm *C.WrenVM) {
-	f := fMap[12]
-	if f == nil {
-		panic("function 12 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(12, vm)
 }
 
 //export f13
 func f13(vm *C.WrenVM) {
-	f := fMap[13]
-	if f == nil {
-		panic("function 13 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(13, vm)
 }
 
 //export f14
 func f14(vm *C.WrenVM) {
-	f := fMap[14]
-	if f == nil {
-		panic("function 14 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(14, vm)
 }
 
 //export f15
 func f15(vm *C.WrenVM) {
-	f := fMap[15]
-	if f == nil {
-		panic("function 15 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(15, vm)
 }
 
 //export f16
 func f16(vm *C.WrenVM) {
-	f := fMap[16]
-	if f == nil {
-		panic("function 16 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(16, vm)
 }
 
 //export f17
 func f17(vm *C.WrenVM) {
-	f := fMap[17]
-	if f == nil {
-		panic("function 17 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(17, vm)
 }
 
 //export f18
 func f18(vm *C.WrenVM) {
-	f := fMap[18]
-	if f == nil {
-		panic("function 18 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(18, vm)
 }
 
 //export f19
 func f19(vm *C.WrenVM) {
-	f := fMap[19]
-	if f == nil {
-		panic("function 19 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(19, vm)
 }
 
 //export f20
 func f20(vm *C.WrenVM) {
-	f := fMap[20]
-	if f == nil {
-		panic("function 20 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(20, vm)
 }
 
 //export f21
 func f21(vm *C.WrenVM) {
-	f := fMap[21]
-	if f == nil {
-		panic("function 21 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(21, vm)
 }
 
 //export f22
 func f22(vm *C.WrenVM) {
-	f := fMap[22]
-	if f == nil {
-		panic("function 22 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(22, vm)
 }
 
 //export f23
 func f23(vm *C.WrenVM) {
-	f := fMap[23]
-	if f == nil {
-		panic("function 23 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(23, vm)
 }
 
 //export f24
 func f24(vm *C.WrenVM) {
-	f := fMap[24]
-	if f == nil {
-		panic("function 24 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(24, vm)
 }
 
 //export f25
 func f25(vm *C.WrenVM) {
-	f := fMap[25]
-	if f == nil {
-		panic("function 25 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(25, vm)
 }
 
 //export f26
 func f26(vm *C.WrenVM) {
-	f := fMap[26]
-	if f == nil {
-		panic("function 26 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(26, vm)
 }
 
 //export f27
 func f27(vm *C.WrenVM) {
-	f := fMap[27]
-	if f == nil {
-		panic("function 27 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(27, vm)
 }
 
 //export f28
 func f28(vm *C.WrenVM) {
-	f := fMap[28]
-	if f == nil {
-		panic("function 28 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(28, vm)
 }
 
 //export f29
 func f29(vm *C.WrenVM) {
-	f := fMap[29]
-	if f == nil {
-		panic("function 29 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(29, vm)
 }
 
 //export f30
 func f30(vm *C.WrenVM) {
-	f := fMap[30]
-	if f == nil {
-		panic("function 30 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(30, vm)
 }
 
 //export f31
 func f31(vm *C.WrenVM) {
-	f := fMap[31]
-	if f == nil {
-		panic("function 31 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(31, vm)
 }
 
 //export f32
 func f32(vm *C.WrenVM) {
-	f := fMap[32]
-	if f == nil {
-		panic("function 32 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(32, vm)
 }
 
 //export f33
 func f33(vm *C.WrenVM) {
-	f := fMap[33]
-	if f == nil {
-		panic("function 33 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(33, vm)
 }
 
 //export f34
 func f34(vm *C.WrenVM) {
-	f := fMap[34]
-	if f == nil {
-		panic("function 34 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(34, vm)
 }
 
 //export f35
 func f35(vm *C.WrenVM) {
-	f := fMap[35]
-	if f == nil {
-		panic("function 35 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(35, vm)
 }
 
 //export f36
 func f36(vm *C.WrenVM) {
-	f := fMap[36]
-	if f == nil {
-		panic("function 36 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(36, vm)
 }
 
 //export f37
 func f37(vm *C.WrenVM) {
-	f := fMap[37]
-	if f == nil {
-		panic("function 37 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(37, vm)
 }
 
 //export f38
 func f38(vm *C.WrenVM) {
-	f := fMap[38]
-	if f == nil {
-		panic("function 38 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(38, vm)
 }
 
 //export f39
 func f39(vm *C.WrenVM) {
-	f := fMap[39]
-	if f == nil {
-		panic("function 39 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(39, vm)
 }
 
 //export f40
 func f40(vm *C.WrenVM) {
-	f := fMap[40]
-	if f == nil {
-		panic("function 40 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(40, vm)
 }
 
 //export f41
 func f41(vm *C.WrenVM) {
-	f := fMap[41]
-	if f == nil {
-		panic("function 41 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(41, vm)
 }
 
 //export f42
 func f42(vm *C.WrenVM) {
-	f := fMap[42]
-	if f == nil {
-		panic("function 42 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(42, vm)
 }
 
 //export f43
 func f43(vm *C.WrenVM) {
-	f := fMap[43]
-	if f == nil {
-		panic("function 43 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(43, vm)
 }
 
 //export f44
 func f44(vm *C.WrenVM) {
-	f := fMap[44]
-	if f == nil {
-		panic("function 44 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(44, vm)
 }
 
 //export f45
 func f45(vm *C.WrenVM) {
-	f := fMap[45]
-	if f == nil {
-		panic("function 45 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(45, vm)
 }
 
 //export f46
 func f46(vm *C.WrenVM) {
-	f := fMap[46]
-	if f == nil {
-		panic("function 46 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(46, vm)
 }
 
 //export f47
 func f47(vm *C.WrenVM) {
-	f := fMap[47]
-	if f == nil {
-		panic("function 47 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(47, vm)
 }
 
 //export f48
 func f48(vm *C.WrenVM) {
-	f := fMap[48]
-	if f == nil {
-		panic("function 48 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(48, vm)
 }
 
 //export f49
 func f49(vm *C.WrenVM) {
-	f := fMap[49]
-	if f == nil {
-		panic("function 49 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(49, vm)
 }
 
 //export f50
 func f50(vm *C.WrenVM) {
-	f := fMap[50]
-	if f == nil {
-		panic("function 50 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(50, vm)
 }
 
 //export f51
 func f51(vm *C.WrenVM) {
-	f := fMap[51]
-	if f == nil {
-		panic("function 51 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(51, vm)
 }
 
 //export f52
 func f52(vm *C.WrenVM) {
-	f := fMap[52]
-	if f == nil {
-		panic("function 52 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(52, vm)
 }
 
 //export f53
 func f53(vm *C.WrenVM) {
-	f := fMap[53]
-	if f == nil {
-		panic("function 53 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(53, vm)
 }
 
 //export f54
 func f54(vm *C.WrenVM) {
-	f := fMap[54]
-	if f == nil {
-		panic("function 54 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(54, vm)
 }
 
 //export f55
 func f55(vm *C.WrenVM) {
-	f := fMap[55]
-	if f == nil {
-		panic("function 55 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(55, vm)
 }
 
 //export f56
 func f56(vm *C.WrenVM) {
-	f := fMap[56]
-	if f == nil {
-		panic("function 56 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(56, vm)
 }
 
 //export f57
 func f57(vm *C.WrenVM) {
-	f := fMap[57]
-	if f == nil {
-		panic("function 57 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(57, vm)
 }
 
 //export f58
 func f58(vm *C.WrenVM) {
-	f := fMap[58]
-	if f == nil {
-		panic("function 58 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(58, vm)
 }
 
 //export f59
 func f59(vm *C.WrenVM) {
-	f := fMap[59]
-	if f == nil {
-		panic("function 59 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(59, vm)
 }
 
 //export f60
 func f60(vm *C.WrenVM) {
-	f := fMap[60]
-	if f == nil {
-		panic("function 60 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(60, vm)
 }
 
 //export f61
 func f61(vm *C.WrenVM) {
-	f := fMap[61]
-	if f == nil {
-		panic("function 61 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(61, vm)
 }
 
 //export f62
 func f62(vm *C.WrenVM) {
-	f := fMap[62]
-	if f == nil {
-		panic("function 62 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(62, vm)
 }
 
 //export f63
 func f63(vm *C.WrenVM) {
-	f := fMap[63]
-	if f == nil {
-		panic("function 63 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(63, vm)
 }
 
 //export f64
 func f64(vm *C.WrenVM) {
-	f := fMap[64]
-	if f == nil {
-		panic("function 64 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(64, vm)
 }
 
 //export f65
 func f65(vm *C.WrenVM) {
-	f := fMap[65]
-	if f == nil {
-		panic("function 65 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(65, vm)
 }
 
 //export f66
 func f66(vm *C.WrenVM) {
-	f := fMap[66]
-	if f == nil {
-		panic("function 66 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(66, vm)
 }
 
 //export f67
 func f67(vm *C.WrenVM) {
-	f := fMap[67]
-	if f == nil {
-		panic("function 67 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(67, vm)
 }
 
 //export f68
 func f68(vm *C.WrenVM) {
-	f := fMap[68]
-	if f == nil {
-		panic("function 68 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(68, vm)
 }
 
 //export f69
 func f69(vm *C.WrenVM) {
-	f := fMap[69]
-	if f == nil {
-		panic("function 69 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(69, vm)
 }
 
 //export f70
 func f70(vm *C.WrenVM) {
-	f := fMap[70]
-	if f == nil {
-		panic("function 70 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(70, vm)
 }
 
 //export f71
 func f71(vm *C.WrenVM) {
-	f := fMap[71]
-	if f == nil {
-		panic("function 71 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(71, vm)
 }
 
 //export f72
 func f72(vm *C.WrenVM) {
-	f := fMap[72]
-	if f == nil {
-		panic("function 72 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(72, vm)
 }
 
 //export f73
 func f73(vm *C.WrenVM) {
-	f := fMap[73]
-	if f == nil {
-		panic("function 73 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(73, vm)
 }
 
 //export f74
 func f74(vm *C.WrenVM) {
-	f := fMap[74]
-	if f == nil {
-		panic("function 74 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(74, vm)
 }
 
 //export f75
 func f75(vm *C.WrenVM) {
-	f := fMap[75]
-	if f == nil {
-		panic("function 75 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(75, vm)
 }
 
 //export f76
 func f76(vm *C.WrenVM) {
-	f := fMap[76]
-	if f == nil {
-		panic("function 76 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(76, vm)
 }
 
 //export f77
 func f77(vm *C.WrenVM) {
-	f := fMap[77]
-	if f == nil {
-		panic("function 77 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(77, vm)
 }
 
 //export f78
 func f78(vm *C.WrenVM) {
-	f := fMap[78]
-	if f == nil {
-		panic("function 78 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(78, vm)
 }
 
 //export f79
 func f79(vm *C.WrenVM) {
-	f := fMap[79]
-	if f == nil {
-		panic("function 79 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(79, vm)
 }
 
 //export f80
 func f80(vm *C.WrenVM) {
-	f := fMap[80]
-	if f == nil {
-		panic("function 80 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(80, vm)
 }
 
 //export f81
 func f81(vm *C.WrenVM) {
-	f := fMap[81]
-	if f == nil {
-		panic("function 81 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(81, vm)
 }
 
 //export f82
 func f82(vm *C.WrenVM) {
-	f := fMap[82]
-	if f == nil {
-		panic("function 82 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(82, vm)
 }
 
 //export f83
 func f83(vm *C.WrenVM) {
-	f := fMap[83]
-	if f == nil {
-		panic("function 83 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(83, vm)
 }
 
 //export f84
 func f84(vm *C.WrenVM) {
-	f := fMap[84]
-	if f == nil {
-		panic("function 84 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(84, vm)
 }
 
 //export f85
 func f85(vm *C.WrenVM) {
-	f := fMap[85]
-	if f == nil {
-		panic("function 85 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(85, vm)
 }
 
 //export f86
 func f86(vm *C.WrenVM) {
-	f := fMap[86]
-	if f == nil {
-		panic("function 86 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(86, vm)
 }
 
 //export f87
 func f87(vm *C.WrenVM) {
-	f := fMap[87]
-	if f == nil {
-		panic("function 87 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(87, vm)
 }
 
 //export f88
 func f88(vm *C.WrenVM) {
-	f := fMap[88]
-	if f == nil {
-		panic("function 88 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(88, vm)
 }
 
 //export f89
 func f89(vm *C.WrenVM) {
-	f := fMap[89]
-	if f == nil {
-		panic("function 89 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(89, vm)
 }
 
 //export f90
 func f90(vm *C.WrenVM) {
-	f := fMap[90]
-	if f == nil {
-		panic("function 90 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(90, vm)
 }
 
 //export f91
 func f91(vm *C.WrenVM) {
-	f := fMap[91]
-	if f == nil {
-		panic("function 91 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(91, vm)
 }
 
 //export f92
 func f92(vm *C.WrenVM) {
-	f := fMap[92]
-	if f == nil {
-		panic("function 92 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(92, vm)
 }
 
 //export f93
 func f93(vm *C.WrenVM) {
-	f := fMap[93]
-	if f == nil {
-		panic("function 93 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(93, vm)
 }
 
 //export f94
 func f94(vm *C.WrenVM) {
-	f := fMap[94]
-	if f == nil {
-		panic("function 94 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(94, vm)
 }
 
 //export f95
 func f95(vm *C.WrenVM) {
-	f := fMap[95]
-	if f == nil {
-		panic("function 95 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(95, vm)
 }
 
 //export f96
 func f96(vm *C.WrenVM) {
-	f := fMap[96]
-	if f == nil {
-		panic("function 96 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(96, vm)
 }
 
 //export f97
 func f97(vm *C.WrenVM) {
-	f := fMap[97]
-	if f == nil {
-		panic("function 97 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(97, vm)
 }
 
 //export f98
 func f98(vm *C.WrenVM) {
-	f := fMap[98]
-	if f == nil {
-		panic("function 98 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(98, vm)
 }
 
 //export f99
 func f99(vm *C.WrenVM) {
-	f := fMap[99]
-	if f == nil {
-		panic("function 99 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(99, vm)
 }
 
 //export f100
 func f100(vm *C.WrenVM) {
-	f := fMap[100]
-	if f == nil {
-		panic("function 100 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(100, vm)
 }
 
 //export f101
 func f101(vm *C.WrenVM) {
-	f := fMap[101]
-	if f == nil {
-		panic("function 101 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(101, vm)
 }
 
 //export f102
 func f102(vm *C.WrenVM) {
-	f := fMap[102]
-	if f == nil {
-		panic("function 102 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(102, vm)
 }
 
 //export f103
 func f103(vm *C.WrenVM) {
-	f := fMap[103]
-	if f == nil {
-		panic("function 103 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(103, vm)
 }
 
 //export f104
 func f104(vm *C.WrenVM) {
-	f := fMap[104]
-	if f == nil {
-		panic("function 104 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(104, vm)
 }
 
 //export f105
 func f105(vm *C.WrenVM) {
-	f := fMap[105]
-	if f == nil {
-		panic("function 105 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(105, vm)
 }
 
 //export f106
 func f106(vm *C.WrenVM) {
-	f := fMap[106]
-	if f == nil {
-		panic("function 106 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(106, vm)
 }
 
 //export f107
 func f107(vm *C.WrenVM) {
-	f := fMap[107]
-	if f == nil {
-		panic("function 107 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(107, vm)
 }
 
 //export f108
 func f108(vm *C.WrenVM) {
-	f := fMap[108]
-	if f == nil {
-		panic("function 108 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(108, vm)
 }
 
 //export f109
 func f109(vm *C.WrenVM) {
-	f := fMap[109]
-	if f == nil {
-		panic("function 109 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(109, vm)
 }
 
 //export f110
 func f110(vm *C.WrenVM) {
-	f := fMap[110]
-	if f == nil {
-		panic("function 110 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(110, vm)
 }
 
 //export f111
 func f111(vm *C.WrenVM) {
-	f := fMap[111]
-	if f == nil {
-		panic("function 111 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(111, vm)
 }
 
 //export f112
 func f112(vm *C.WrenVM) {
-	f := fMap[112]
-	if f == nil {
-		panic("function 112 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(112, vm)
 }
 
 //export f113
 func f113(vm *C.WrenVM) {
-	f := fMap[113]
-	if f == nil {
-		panic("function 113 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(113, vm)
 }
 
 //export f114
 func f114(vm *C.WrenVM) {
-	f := fMap[114]
-	if f == nil {
-		panic("function 114 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(114, vm)
 }
 
 //export f115
 func f115(vm *C.WrenVM) {
-	f := fMap[115]
-	if f == nil {
-		panic("function 115 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(115, vm)
 }
 
 //export f116
 func f116(vm *C.WrenVM) {
-	f := fMap[116]
-	if f == nil {
-		panic("function 116 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(116, vm)
 }
 
 //export f117
 func f117(vm *C.WrenVM) {
-	f := fMap[117]
-	if f == nil {
-		panic("function 117 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(117, vm)
 }
 
 //export f118
 func f118(vm *C.WrenVM) {
-	f := fMap[118]
-	if f == nil {
-		panic("function 118 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(118, vm)
 }
 
 //export f119
 func f119(vm *C.WrenVM) {
-	f := fMap[119]
-	if f == nil {
-		panic("function 119 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(119, vm)
 }
 
 //export f120
 func f120(vm *C.WrenVM) {
-	f := fMap[120]
-	if f == nil {
-		panic("function 120 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(120, vm)
 }
 
 //export f121
 func f121(vm *C.WrenVM) {
-	f := fMap[121]
-	if f == nil {
-		panic("function 121 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(121, vm)
 }
 
 //export f122
 func f122(vm *C.WrenVM) {
-	f := fMap[122]
-	if f == nil {
-		panic("function 122 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(122, vm)
 }
 
 //export f123
 func f123(vm *C.WrenVM) {
-	f := fMap[123]
-	if f == nil {
-		panic("function 123 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(123, vm)
 }
 
 //export f124
 func f124(vm *C.WrenVM) {
-	f := fMap[124]
-	if f == nil {
-		panic("function 124 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(124, vm)
 }
 
 //export f125
 func f125(vm *C.WrenVM) {
-	f := fMap[125]
-	if f == nil {
-		panic("function 125 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(125, vm)
 }
 
 //export f126
 func f126(vm *C.WrenVM) {
-	f := fMap[126]
-	if f == nil {
-		panic("function 126 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(126, vm)
 }
 
 //export f127
 func f127(vm *C.WrenVM) {
-	f := fMap[127]
-	if f == nil {
-		panic("function 127 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(127, vm)
 }
 
 //export f128
 func f128(vm *C.WrenVM) {
-	f := fMap[128]
-	if f == nil {
-		panic("function 128 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(128, vm)
 }
 
 //export f129
 func f129(vm *C.WrenVM) {
-	f := fMap[129]
-	if f == nil {
-		panic("function 129 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(129, vm)
 }
 
 //export f130
 func f130(vm *C.WrenVM) {
-	f := fMap[130]
-	if f == nil {
-		panic("function 130 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(130, vm)
 }
 
 //export f131
 func f131(vm *C.WrenVM) {
-	f := fMap[131]
-	if f == nil {
-		panic("function 131 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(131, vm)
 }
 
 //export f132
 func f132(vm *C.WrenVM) {
-	f := fMap[132]
-	if f == nil {
-		panic("function 132 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(132, vm)
 }
 
 //export f133
 func f133(vm *C.WrenVM) {
-	f := fMap[133]
-	if f == nil {
-		panic("function 133 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(133, vm)
 }
 
 //export f134
 func f134(vm *C.WrenVM) {
-	f := fMap[134]
-	if f == nil {
-		panic("function 134 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(134, vm)
 }
 
 //export f135
 func f135(vm *C.WrenVM) {
-	f := fMap[135]
-	if f == nil {
-		panic("function 135 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(135, vm)
 }
 
 //export f136
 func f136(vm *C.WrenVM) {
-	f := fMap[136]
-	if f == nil {
-		panic("function 136 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(136, vm)
 }
 
 //export f137
 func f137(vm *C.WrenVM) {
-	f := fMap[137]
-	if f == nil {
-		panic("function 137 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(137, vm)
 }
 
 //export f138
 func f138(vm *C.WrenVM) {
-	f := fMap[138]
-	if f == nil {
-		panic("function 138 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(138, vm)
 }
 
 //export f139
 func f139(vm *C.WrenVM) {
-	f := fMap[139]
-	if f == nil {
-		panic("function 139 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(139, vm)
 }
 
 //export f140
 func f140(vm *C.WrenVM) {
-	f := fMap[140]
-	if f == nil {
-		panic("function 140 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(140, vm)
 }
 
 //export f141
 func f141(vm *C.WrenVM) {
-	f := fMap[141]
-	if f == nil {
-		panic("function 141 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(141, vm)
 }
 
 //export f142
 func f142(vm *C.WrenVM) {
-	f := fMap[142]
-	if f == nil {
-		panic("function 142 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(142, vm)
 }
 
 //export f143
 func f143(vm *C.WrenVM) {
-	f := fMap[143]
-	if f == nil {
-		panic("function 143 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(143, vm)
 }
 
 //export f144
 func f144(vm *C.WrenVM) {
-	f := fMap[144]
-	if f == nil {
-		panic("function 144 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(144, vm)
 }
 
 //export f145
 func f145(vm *C.WrenVM) {
-	f := fMap[145]
-	if f == nil {
-		panic("function 145 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(145, vm)
 }
 
 //export f146
 func f146(vm *C.WrenVM) {
-	f := fMap[146]
-	if f == nil {
-		panic("function 146 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(146, vm)
 }
 
 //export f147
 func f147(vm *C.WrenVM) {
-	f := fMap[147]
-	if f == nil {
-		panic("function 147 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(147, vm)
 }
 
 //export f148
 func f148(vm *C.WrenVM) {
-	f := fMap[148]
-	if f == nil {
-		panic("function 148 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(148, vm)
 }
 
 //export f149
 func f149(vm *C.WrenVM) {
-	f := fMap[149]
-	if f == nil {
-		panic("function 149 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(149, vm)
 }
 
 //export f150
 func f150(vm *C.WrenVM) {
-	f := fMap[150]
-	if f == nil {
-		panic("function 150 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(150, vm)
 }
 
 //export f151
 func f151(vm *C.WrenVM) {
-	f := fMap[151]
-	if f == nil {
-		panic("function 151 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(151, vm)
 }
 
 //export f152
 func f152(vm *C.WrenVM) {
-	f := fMap[152]
-	if f == nil {
-		panic("function 152 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(152, vm)
 }
 
 //export f153
 func f153(vm *C.WrenVM) {
-	f := fMap[153]
-	if f == nil {
-		panic("function 153 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(153, vm)
 }
 
 //export f154
 func f154(vm *C.WrenVM) {
-	f := fMap[154]
-	if f == nil {
-		panic("function 154 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(154, vm)
 }
 
 //export f155
 func f155(vm *C.WrenVM) {
-	f := fMap[155]
-	if f == nil {
-		panic("function 155 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(155, vm)
 }
 
 //export f156
 func f156(vm *C.WrenVM) {
-	f := fMap[156]
-	if f == nil {
-		panic("function 156 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(156, vm)
 }
 
 //export f157
 func f157(vm *C.WrenVM) {
-	f := fMap[157]
-	if f == nil {
-		panic("function 157 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(157, vm)
 }
 
 //export f158
 func f158(vm *C.WrenVM) {
-	f := fMap[158]
-	if f == nil {
-		panic("function 158 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(158, vm)
 }
 
 //export f159
 func f159(vm *C.WrenVM) {
-	f := fMap[159]
-	if f == nil {
-		panic("function 159 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(159, vm)
 }
 
 //export f160
 func f160(vm *C.WrenVM) {
-	f := fMap[160]
-	if f == nil {
-		panic("function 160 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(160, vm)
 }
 
 //export f161
 func f161(vm *C.WrenVM) {
-	f := fMap[161]
-	if f == nil {
-		panic("function 161 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(161, vm)
 }
 
 //export f162
 func f162(vm *C.WrenVM) {
-	f := fMap[162]
-	if f == nil {
-		panic("function 162 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(162, vm)
 }
 
 //export f163
 func f163(vm *C.WrenVM) {
-	f := fMap[163]
-	if f == nil {
-		panic("function 163 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(163, vm)
 }
 
 //export f164
 func f164(vm *C.WrenVM) {
-	f := fMap[164]
-	if f == nil {
-		panic("function 164 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(164, vm)
 }
 
 //export f165
 func f165(vm *C.WrenVM) {
-	f := fMap[165]
-	if f == nil {
-		panic("function 165 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(165, vm)
 }
 
 //export f166
 func f166(vm *C.WrenVM) {
-	f := fMap[166]
-	if f == nil {
-		panic("function 166 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(166, vm)
 }
 
 //export f167
 func f167(vm *C.WrenVM) {
-	f := fMap[167]
-	if f == nil {
-		panic("function 167 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(167, vm)
 }
 
 //export f168
 func f168(vm *C.WrenVM) {
-	f := fMap[168]
-	if f == nil {
-		panic("function 168 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(168, vm)
 }
 
 //export f169
 func f169(vm *C.WrenVM) {
-	f := fMap[169]
-	if f == nil {
-		panic("function 169 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(169, vm)
 }
 
 //export f170
 func f170(vm *C.WrenVM) {
-	f := fMap[170]
-	if f == nil {
-		panic("function 170 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(170, vm)
 }
 
 //export f171
 func f171(vm *C.WrenVM) {
-	f := fMap[171]
-	if f == nil {
-		panic("function 171 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(171, vm)
 }
 
 //export f172
 func f172(vm *C.WrenVM) {
-	f := fMap[172]
-	if f == nil {
-		panic("function 172 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(172, vm)
 }
 
 //export f173
 func f173(vm *C.WrenVM) {
-	f := fMap[173]
-	if f == nil {
-		panic("function 173 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(173, vm)
 }
 
 //export f174
 func f174(vm *C.WrenVM) {
-	f := fMap[174]
-	if f == nil {
-		panic("function 174 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(174, vm)
 }
 
 //export f175
 func f175(vm *C.WrenVM) {
-	f := fMap[175]
-	if f == nil {
-		panic("function 175 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(175, vm)
 }
 
 //export f176
 func f176(vm *C.WrenVM) {
-	f := fMap[176]
-	if f == nil {
-		panic("function 176 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(176, vm)
 }
 
 //export f177
 func f177(vm *C.WrenVM) {
-	f := fMap[177]
-	if f == nil {
-		panic("function 177 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(177, vm)
 }
 
 //export f178
 func f178(vm *C.WrenVM) {
-	f := fMap[178]
-	if f == nil {
-		panic("function 178 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(178, vm)
 }
 
 //export f179
 func f179(vm *C.WrenVM) {
-	f := fMap[179]
-	if f == nil {
-		panic("function 179 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(179, vm)
 }
 
 //export f180
 func f180(vm *C.WrenVM) {
-	f := fMap[180]
-	if f == nil {
-		panic("function 180 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(180, vm)
 }
 
 //export f181
 func f181(vm *C.WrenVM) {
-	f := fMap[181]
-	if f == nil {
-		panic("function 181 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(181, vm)
 }
 
 //export f182
 func f182(vm *C.WrenVM) {
-	f := fMap[182]
-	if f == nil {
-		panic("function 182 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(182, vm)
 }
 
 //export f183
 func f183(vm *C.WrenVM) {
-	f := fMap[183]
-	if f == nil {
-		panic("function 183 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(183, vm)
 }
 
 //export f184
 func f184(vm *C.WrenVM) {
-	f := fMap[184]
-	if f == nil {
-		panic("function 184 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(184, vm)
 }
 
 //export f185
 func f185(vm *C.WrenVM) {
-	f := fMap[185]
-	if f == nil {
-		panic("function 185 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(185, vm)
 }
 
 //export f186
 func f186(vm *C.WrenVM) {
-	f := fMap[186]
-	if f == nil {
-		panic("function 186 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(186, vm)
 }
 
 //export f187
 func f187(vm *C.WrenVM) {
-	f := fMap[187]
-	if f == nil {
-		panic("function 187 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(187, vm)
 }
 
 //export f188
 func f188(vm *C.WrenVM) {
-	f := fMap[188]
-	if f == nil {
-		panic("function 188 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(188, vm)
 }
 
 //export f189
 func f189(vm *C.WrenVM) {
-	f := fMap[189]
-	if f == nil {
-		panic("function 189 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(189, vm)
 }
 
 //export f190
 func f190(vm *C.WrenVM) {
-	f := fMap[190]
-	if f == nil {
-		panic("function 190 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(190, vm)
 }
 
 //export f191
 func f191(vm *C.WrenVM) {
-	f := fMap[191]
-	if f == nil {
-		panic("function 191 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(191, vm)
 }
 
 //export f192
 func f192(vm *C.WrenVM) {
-	f := fMap[192]
-	if f == nil {
-		panic("function 192 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(192, vm)
 }
 
 //export f193
 func f193(vm *C.WrenVM) {
-	f := fMap[193]
-	if f == nil {
-		panic("function 193 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(193, vm)
 }
 
 //export f194
 func f194(vm *C.WrenVM) {
-	f := fMap[194]
-	if f == nil {
-		panic("function 194 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(194, vm)
 }
 
 //export f195
 func f195(vm *C.WrenVM) {
-	f := fMap[195]
-	if f == nil {
-		panic("function 195 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(195, vm)
 }
 
 //export f196
 func f196(vm *C.WrenVM) {
-	f := fMap[196]
-	if f == nil {
-		panic("function 196 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(196, vm)
 }
 
 //export f197
 func f197(vm *C.WrenVM) {
-	f := fMap[197]
-	if f == nil {
-		panic("function 197 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(197, vm)
 }
 
 //export f198
 func f198(vm *C.WrenVM) {
-	f := fMap[198]
-	if f == nil {
-		panic("function 198 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(198, vm)
 }
 
 //export f199
 func f199(vm *C.WrenVM) {
-	f := fMap[199]
-	if f == nil {
-		panic("function 199 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(199, vm)
 }
 
 //export f200
 func f200(vm *C.WrenVM) {
-	f := fMap[200]
-	if f == nil {
-		panic("function 200 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(200, vm)
 }
 
 //export f201
 func f201(vm *C.WrenVM) {
-	f := fMap[201]
-	if f == nil {
-		panic("function 201 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(201, vm)
 }
 
 //export f202
 func f202(vm *C.WrenVM) {
-	f := fMap[202]
-	if f == nil {
-		panic("function 202 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(202, vm)
 }
 
 //export f203
 func f203(vm *C.WrenVM) {
-	f := fMap[203]
-	if f == nil {
-		panic("function 203 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(203, vm)
 }
 
 //export f204
 func f204(vm *C.WrenVM) {
-	f := fMap[204]
-	if f == nil {
-		panic("function 204 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(204, vm)
 }
 
 //export f205
 func f205(vm *C.WrenVM) {
-	f := fMap[205]
-	if f == nil {
-		panic("function 205 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(205, vm)
 }
 
 //export f206
 func f206(vm *C.WrenVM) {
-	f := fMap[206]
-	if f == nil {
-		panic("function 206 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(206, vm)
 }
 
 //export f207
 func f207(vm *C.WrenVM) {
-	f := fMap[207]
-	if f == nil {
-		panic("function 207 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(207, vm)
 }
 
 //export f208
 func f208(vm *C.WrenVM) {
-	f := fMap[208]
-	if f == nil {
-		panic("function 208 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(208, vm)
 }
 
 //export f209
 func f209(vm *C.WrenVM) {
-	f := fMap[209]
-	if f == nil {
-		panic("function 209 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(209, vm)
 }
 
 //export f210
 func f210(vm *C.WrenVM) {
-	f := fMap[210]
-	if f == nil {
-		panic("function 210 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(210, vm)
 }
 
 //export f211
 func f211(vm *C.WrenVM) {
-	f := fMap[211]
-	if f == nil {
-		panic("function 211 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(211, vm)
 }
 
 //export f212
 func f212(vm *C.WrenVM) {
-	f := fMap[212]
-	if f == nil {
-		panic("function 212 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(212, vm)
 }
 
 //export f213
 func f213(vm *C.WrenVM) {
-	f := fMap[213]
-	if f == nil {
-		panic("function 213 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(213, vm)
 }
 
 //export f214
 func f214(vm *C.WrenVM) {
-	f := fMap[214]
-	if f == nil {
-		panic("function 214 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(214, vm)
 }
 
 //export f215
 func f215(vm *C.WrenVM) {
-	f := fMap[215]
-	if f == nil {
-		panic("function 215 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(215, vm)
 }
 
 //export f216
 func f216(vm *C.WrenVM) {
-	f := fMap[216]
-	if f == nil {
-		panic("function 216 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(216, vm)
 }
 
 //export f217
 func f217(vm *C.WrenVM) {
-	f := fMap[217]
-	if f == nil {
-		panic("function 217 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(217, vm)
 }
 
 //export f218
 func f218(vm *C.WrenVM) {
-	f := fMap[218]
-	if f == nil {
-		panic("function 218 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(218, vm)
 }
 
 //export f219
 func f219(vm *C.WrenVM) {
-	f := fMap[219]
-	if f == nil {
-		panic("function 219 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(219, vm)
 }
 
 //export f220
 func f220(vm *C.WrenVM) {
-	f := fMap[220]
-	if f == nil {
-		panic("function 220 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(220, vm)
 }
 
 //export f221
 func f221(vm *C.WrenVM) {
-	f := fMap[221]
-	if f == nil {
-		panic("function 221 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(221, vm)
 }
 
 //export f222
 func f222(vm *C.WrenVM) {
-	f := fMap[222]
-	if f == nil {
-		panic("function 222 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(222, vm)
 }
 
 //export f223
 func f223(vm *C.WrenVM) {
-	f := fMap[223]
-	if f == nil {
-		panic("function 223 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(223, vm)
 }
 
 //export f224
 func f224(vm *C.WrenVM) {
-	f := fMap[224]
-	if f == nil {
-		panic("function 224 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(224, vm)
 }
 
 //export f225
 func f225(vm *C.WrenVM) {
-	f := fMap[225]
-	if f == nil {
-		panic("function 225 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(225, vm)
 }
 
 //export f226
 func f226(vm *C.WrenVM) {
-	f := fMap[226]
-	if f == nil {
-		panic("function 226 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(226, vm)
 }
 
 //export f227
 func f227(vm *C.WrenVM) {
-	f := fMap[227]
-	if f == nil {
-		panic("function 227 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(227, vm)
 }
 
 //export f228
 func f228(vm *C.WrenVM) {
-	f := fMap[228]
-	if f == nil {
-		panic("function 228 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(228, vm)
 }
 
 //export f229
 func f229(vm *C.WrenVM) {
-	f := fMap[229]
-	if f == nil {
-		panic("function 229 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(229, vm)
 }
 
 //export f230
 func f230(vm *C.WrenVM) {
-	f := fMap[230]
-	if f == nil {
-		panic("function 230 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(230, vm)
 }
 
 //export f231
 func f231(vm *C.WrenVM) {
-	f := fMap[231]
-	if f == nil {
-		panic("function 231 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(231, vm)
 }
 
 //export f232
 func f232(vm *C.WrenVM) {
-	f := fMap[232]
-	if f == nil {
-		panic("function 232 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(232, vm)
 }
 
 //export f233
 func f233(vm *C.WrenVM) {
-	f := fMap[233]
-	if f == nil {
-		panic("function 233 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(233, vm)
 }
 
 //export f234
 func f234(vm *C.WrenVM) {
-	f := fMap[234]
-	if f == nil {
-		panic("function 234 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(234, vm)
 }
 
 //export f235
 func f235(vm *C.WrenVM) {
-	f := fMap[235]
-	if f == nil {
-		panic("function 235 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(235, vm)
 }
 
 //export f236
 func f236(vm *C.WrenVM) {
-	f := fMap[236]
-	if f == nil {
-		panic("function 236 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(236, vm)
 }
 
 //export f237
 func f237(vm *C.WrenVM) {
-	f := fMap[237]
-	if f == nil {
-		panic("function 237 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(237, vm)
 }
 
 //export f238
 func f238(vm *C.WrenVM) {
-	f := fMap[238]
-	if f == nil {
-		panic("function 238 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(238, vm)
 }
 
 //export f239
 func f239(vm *C.WrenVM) {
-	f := fMap[239]
-	if f == nil {
-		panic("function 239 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(239, vm)
 }
 
 //export f240
 func f240(vm *C.WrenVM) {
-	f := fMap[240]
-	if f == nil {
-		panic("function 240 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(240, vm)
 }
 
 //export f241
 func f241(vm *C.WrenVM) {
-	f := fMap[241]
-	if f == nil {
-		panic("function 241 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(241, vm)
 }
 
 //export f242
 func f242(vm *C.WrenVM) {
-	f := fMap[242]
-	if f == nil {
-		panic("function 242 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(242, vm)
 }
 
 //export f243
 func f243(vm *C.WrenVM) {
-	f := fMap[243]
-	if f == nil {
-		panic("function 243 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(243, vm)
 }
 
 //export f244
 func f244(vm *C.WrenVM) {
-	f := fMap[244]
-	if f == nil {
-		panic("function 244 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(244, vm)
 }
 
 //export f245
 func f245(vm *C.WrenVM) {
-	f := fMap[245]
-	if f == nil {
-		panic("function 245 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(245, vm)
 }
 
 //export f246
 func f246(vm *C.WrenVM) {
-	f := fMap[246]
-	if f == nil {
-		panic("function 246 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(246, vm)
 }
 
 //export f247
 func f247(vm *C.WrenVM) {
-	f := fMap[247]
-	if f == nil {
-		panic("function 247 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(247, vm)
 }
 
 //export f248
 func f248(vm *C.WrenVM) {
-	f := fMap[248]
-	if f == nil {
-		panic("function 248 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(248, vm)
 }
 
 //export f249
 func f249(vm *C.WrenVM) {
-	f := fMap[249]
-	if f == nil {
-		panic("function 249 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(249, vm)
 }
 
 //export f250
 func f250(vm *C.WrenVM) {
-	f := fMap[250]
-	if f == nil {
-		panic("function 250 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(250, vm)
 }
 
 //export f251
 func f251(vm *C.WrenVM) {
-	f := fMap[251]
-	if f == nil {
-		panic("function 251 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(251, vm)
 }
 
 //export f252
 func f252(vm *C.WrenVM) {
-	f := fMap[252]
-	if f == nil {
-		panic("function 252 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(252, vm)
 }
 
 //export f253
 func f253(vm *C.WrenVM) {
-	f := fMap[253]
-	if f == nil {
-		panic("function 253 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(253, vm)
 }
 
 //export f254
 func f254(vm *C.WrenVM) {
-	f := fMap[254]
-	if f == nil {
-		panic("function 254 not registered")
-	}
-	f(vmMap[vm])
+	callFunc(254, vm)
 }
 
 //export f255
 func f255(vm *C.WrenVM) {
-	f := fMap[255]
+	callFunc(255, vm)
+}
+
+
+// Functions may be registered by one VM while another one is calling them.
+func callFunc(i int, vm *C.WrenVM) {
+	fMapGuard.Lock()
+	f := fMap[i]
+	fMapGuard.Unlock()
+
 	if f == nil {
-		panic("function 255 not registered")
+		panic(fmt.Sprintf("function %d not registered", i))
 	}
-	f(vmMap[vm])
+	f(lookupVM(vm))
 }
 
-
 func registerFunc(name string, f func(*VM)) (unsafe.Pointer, error) {
+	fMapGuard.Lock()
+	defer fMapGuard.Unlock()
+
 	if (fCounter+1) >= MAX_FUNC_REGISTRATIONS {
 		return nil, errors.New("maximum function registration reached")
 	}
 
-	fMapGuard.Lock()
-	defer fMapGuard.Unlock()
-
 	fMap[fCounter] = f
 	ptr := C.get_f(C.int(fCounter))
 	fCounter++
@@ -0,0 +1,394 @@
+package wrengo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// The built-in "go" module, letting scripts use any Go value the host gives
+// them with [VM.SetSlotGoObject], without declaring a foreign class for it:
+//
+//	import "go" for GoObject
+//
+//	var order = Shop.order(42) // A GoObject.
+//	System.print(order["Total"])
+//	order["Note"] = "Gift wrap"
+//	order.call("AddItem", ["book", 2])
+//	System.print(order.call("Items"))
+//
+// Exported methods are called by name, and exported fields of structs and
+// entries of maps are read and set with subscripts. Arguments are converted to
+// the types the method expects, and results that aren't booleans, numbers,
+// strings, lists or maps of those are GoObjects themselves. A method whose
+// last result is a non-nil error aborts the fiber with it.
+var goModule = &Module{
+	Name: "go",
+	Source: `
+import "wrengo/value" for Value
+
+foreign class GoObject {
+  call(method) { call(method, []) }
+  call(method, args) {
+    if (!(method is String)) Fiber.abort("Method must be a string.")
+    if (!(args is List)) Fiber.abort("Arguments must be a list.")
+    return Value.decode(call_(method, args.map {|arg| GoObject.encode_(arg) }.toList))
+  }
+
+  [name] {
+    if (!(name is String)) Fiber.abort("Name must be a string.")
+    return Value.decode(get_(name))
+  }
+
+  [name]=(value) {
+    if (!(name is String)) Fiber.abort("Name must be a string.")
+    set_(name, GoObject.encode_(value))
+    return value
+  }
+
+  // The Go type of the value, like "*main.Order".
+  foreign type
+
+  foreign toString
+
+  static encode_(value) { value is GoObject ? value : Value.encode(value) }
+
+  foreign call_(method, args)
+  foreign get_(name)
+  foreign set_(name, value)
+}
+`,
+	Methods: map[string]func(*VM){
+		"GoObject.type":       goObjectType,
+		"GoObject.toString":   goObjectToString,
+		"GoObject.call_(_,_)": goObjectCall,
+		"GoObject.get_(_)":    goObjectGet,
+		"GoObject.set_(_,_)":  goObjectSet,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		// Instances are only created by [VM.SetSlotGoObject].
+		"GoObject": func(*VM) interface{} { return nil },
+	},
+}
+
+// The Go value of a GoObject. Values are wrapped, so the ones implementing
+// [io.Closer] aren't closed once the GoObject is garbage collected.
+type goObject struct {
+	value reflect.Value
+}
+
+// Stores [value] in [slot] as a GoObject of the "go" module, so scripts can
+// call its methods and access its fields. A nil value is stored as null.
+//
+// If no script imported the module yet, it is loaded first, which must not
+// happen from a foreign method.
+func (vm *VM) SetSlotGoObject(slot int, value interface{}) error {
+	if value == nil {
+		vm.SetSlotNull(slot)
+		return nil
+	}
+	if err := vm.require(goModule); err != nil {
+		return err
+	}
+	vm.setSlotGoObject(slot, reflect.ValueOf(value))
+	return nil
+}
+
+func (vm *VM) setSlotGoObject(slot int, value reflect.Value) {
+	class := vm.scratchSlot()
+	vm.GetVariable("go", "GoObject", class)
+	vm.SetSlotNewObject(slot, class, &goObject{value: value})
+}
+
+// Returns the Go value of the GoObject in [slot], and whether the slot holds
+// one.
+func (vm *VM) GetSlotGoObject(slot int) (interface{}, bool) {
+	if vm.GetSlotType(slot) != WREN_TYPE_FOREIGN {
+		return nil, false
+	}
+	o, ok := vm.GetSlotObject(slot).(*goObject)
+	if !ok {
+		return nil, false
+	}
+	return o.value.Interface(), true
+}
+
+func goObjectOf(vm *VM) reflect.Value {
+	return vm.GetSlotObject(0).(*goObject).value
+}
+
+func goObjectType(vm *VM) {
+	vm.SetSlotString(0, goObjectOf(vm).Type().String())
+}
+
+func goObjectToString(vm *VM) {
+	vm.SetSlotString(0, fmt.Sprint(goObjectOf(vm).Interface()))
+}
+
+func goObjectCall(vm *VM) {
+	var (
+		object = goObjectOf(vm)
+		name   = vm.GetSlotString(1)
+	)
+
+	method := object.MethodByName(name)
+	if !method.IsValid() {
+		vm.abortFiber(fmt.Sprintf("%s has no method '%s'.", object.Type(), name))
+		return
+	}
+
+	var args []interface{}
+	element := vm.scratchSlot()
+	for i := 0; i < vm.GetListCount(2); i++ {
+		vm.GetListElement(2, i, element)
+		arg, err := vm.goObjectArg(element)
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		args = append(args, arg)
+	}
+
+	result, err := goCall(method, args)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.setSlotGoValue(0, result)
+}
+
+func goObjectGet(vm *VM) {
+	var (
+		object = reflect.Indirect(goObjectOf(vm))
+		name   = vm.GetSlotString(1)
+	)
+	if !object.IsValid() {
+		vm.abortFiber("Cannot access a field of a nil object.")
+		return
+	}
+
+	switch object.Kind() {
+	case reflect.Struct:
+		field := object.FieldByName(name)
+		if !field.IsValid() || !field.CanInterface() {
+			vm.abortFiber(fmt.Sprintf("%s has no field '%s'.", object.Type(), name))
+			return
+		}
+		vm.setSlotGoValue(0, field)
+	case reflect.Map:
+		key, err := goConvert(name, object.Type().Key())
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		if value := object.MapIndex(key); value.IsValid() {
+			vm.setSlotGoValue(0, value)
+		} else {
+			vm.SetSlotNull(0)
+		}
+	default:
+		vm.abortFiber(fmt.Sprintf("%s has no fields.", object.Type()))
+	}
+}
+
+func goObjectSet(vm *VM) {
+	var (
+		object = reflect.Indirect(goObjectOf(vm))
+		name   = vm.GetSlotString(1)
+	)
+	if !object.IsValid() {
+		vm.abortFiber("Cannot access a field of a nil object.")
+		return
+	}
+
+	arg, err := vm.goObjectArg(2)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	switch object.Kind() {
+	case reflect.Struct:
+		field := object.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			vm.abortFiber(fmt.Sprintf("%s has no settable field '%s'.", object.Type(), name))
+			return
+		}
+		value, err := goConvert(arg, field.Type())
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+		field.Set(value)
+	case reflect.Map:
+		key, err := goConvert(name, object.Type().Key())
+		if err == nil {
+			var value reflect.Value
+			value, err = goConvert(arg, object.Type().Elem())
+			if err == nil {
+				object.SetMapIndex(key, value)
+			}
+		}
+		if err != nil {
+			vm.abortFiber(err.Error())
+			return
+		}
+	default:
+		vm.abortFiber(fmt.Sprintf("%s has no fields.", object.Type()))
+		return
+	}
+	vm.SetSlotNull(0)
+}
+
+// Reads an argument given to a GoObject, either another GoObject or a value
+// encoded by `Value.encode(_)`.
+func (vm *VM) goObjectArg(slot int) (interface{}, error) {
+	if value, ok := vm.GetSlotGoObject(slot); ok {
+		return value, nil
+	}
+	return vm.getSlotEncoded(slot)
+}
+
+// Stores the result of a GoObject in [slot], encoded as by `Value.encode(_)`
+// if it is a plain value and as a GoObject otherwise.
+func (vm *VM) setSlotGoValue(slot int, value reflect.Value) {
+	if !value.IsValid() {
+		vm.SetSlotNull(slot)
+		return
+	}
+	if err := vm.setSlotEncoded(slot, value.Interface()); err != nil {
+		// Values held by interfaces are wrapped as their dynamic type.
+		vm.setSlotGoObject(slot, reflect.ValueOf(value.Interface()))
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Calls [method] with [args] converted to the types of its parameters.
+//
+// Returns its only result, a slice of its results if it has several, or an
+// invalid value if it has none. A last result of type error is returned as the
+// error instead.
+func goCall(method reflect.Value, args []interface{}) (result reflect.Value, err error) {
+	t := method.Type()
+	switch {
+	case t.IsVariadic() && len(args) < t.NumIn()-1:
+		return reflect.Value{}, fmt.Errorf("Method expects at least %d arguments, got %d.", t.NumIn()-1, len(args))
+	case !t.IsVariadic() && len(args) != t.NumIn():
+		return reflect.Value{}, fmt.Errorf("Method expects %d arguments, got %d.", t.NumIn(), len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var param reflect.Type
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			param = t.In(t.NumIn() - 1).Elem()
+		} else {
+			param = t.In(i)
+		}
+
+		if in[i], err = goConvert(arg, param); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	// Go panics mustn't unwind through the C frames of Wren.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Go panic: %v", r)
+		}
+	}()
+	out := method.Call(in)
+
+	if n := len(out); n > 0 && t.Out(n-1) == errorType {
+		if e, _ := out[n-1].Interface().(error); e != nil {
+			return reflect.Value{}, e
+		}
+		out = out[:n-1]
+	}
+
+	switch len(out) {
+	case 0:
+		return reflect.Value{}, nil
+	case 1:
+		return out[0], nil
+	default:
+		results := make([]interface{}, len(out))
+		for i, v := range out {
+			results[i] = v.Interface()
+		}
+		return reflect.ValueOf(results), nil
+	}
+}
+
+// Converts a value read from Wren to type [t].
+func goConvert(value interface{}, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("Cannot convert null to %s.", t)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		// Numbers must fit, since converting them would silently wrap.
+		zero := reflect.Zero(t)
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 || zero.OverflowInt(int64(v)) {
+				return reflect.Value{}, fmt.Errorf("Cannot convert %v to %s.", v, t)
+			}
+			return reflect.ValueOf(int64(v)).Convert(t), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 || zero.OverflowUint(uint64(v)) {
+				return reflect.Value{}, fmt.Errorf("Cannot convert %v to %s.", v, t)
+			}
+			return reflect.ValueOf(uint64(v)).Convert(t), nil
+		case reflect.Float32, reflect.Float64:
+			if zero.OverflowFloat(v) {
+				return reflect.Value{}, fmt.Errorf("Cannot convert %v to %s.", v, t)
+			}
+			return reflect.ValueOf(v).Convert(t), nil
+		}
+	case string:
+		if t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf(v).Convert(t), nil
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(t, len(v), len(v))
+			for i, element := range v {
+				e, err := goConvert(element, t.Elem())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				slice.Index(i).Set(e)
+			}
+			return slice, nil
+		}
+	case map[interface{}]interface{}:
+		if t.Kind() == reflect.Map {
+			m := reflect.MakeMapWithSize(t, len(v))
+			for key, element := range v {
+				k, err := goConvert(key, t.Key())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				e, err := goConvert(element, t.Elem())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				m.SetMapIndex(k, e)
+			}
+			return m, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("Cannot convert %T to %s.", value, t)
+}
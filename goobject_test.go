@@ -0,0 +1,122 @@
+package wrengo
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOrder struct {
+	Total    float64
+	Note     string
+	Customer *testCustomer
+	Count    uint
+	Priority int8
+	items    []string
+}
+
+type testCustomer struct {
+	Name string
+}
+
+func (o *testOrder) AddItem(name string, count int) {
+	for i := 0; i < count; i++ {
+		o.items = append(o.items, name)
+	}
+}
+
+func (o *testOrder) Items() []string {
+	return o.items
+}
+
+func (o *testOrder) Join(separator string, items ...string) string {
+	return strings.Join(items, separator)
+}
+
+func (o *testOrder) Check() (bool, error) {
+	return false, errors.New("Order is empty.")
+}
+
+func TestGoObject(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new {|order|
+		System.print([order.type, order["Total"], order["Customer"]["Name"]])
+		order["Note"] = "Gift wrap"
+		order.call("AddItem", ["book", 2])
+		order["Priority"] = -3
+		System.print(order.call("Items"))
+		System.print(order.call("Join", ["-", "a", "b"]))
+		for (fn in [
+			Fn.new { order.call("Check") },
+			Fn.new { order.call("AddItem", ["book", 1.5]) },
+			Fn.new { order.call("Missing") },
+			Fn.new { order["items"] },
+			Fn.new { order["Total"] = "free" },
+			Fn.new { order["Count"] = -1 },
+			Fn.new { order["Priority"] = 300 }
+		]) {
+			System.print(Fiber.new(fn).try())
+		}
+		return order["Customer"]
+	}`)
+	defer fn.Release()
+
+	order := &testOrder{Total: 12.5, Customer: &testCustomer{Name: "wren"}}
+	call := vm.NewCallHandle("call(_)")
+	defer call.Release()
+	vm.EnsureSlots(2)
+	vm.SetSlotHandle(0, fn)
+	assert.NoError(t, vm.SetSlotGoObject(1, order))
+	assert.NoError(t, call.Call())
+
+	customer, ok := vm.GetSlotGoObject(0)
+	assert.True(t, ok)
+	assert.Same(t, order.Customer, customer)
+	assert.Equal(t, "Gift wrap", order.Note)
+	assert.Equal(t, []string{"book", "book"}, order.items)
+	assert.Equal(t, int8(-3), order.Priority)
+	assert.Equal(t, uint(0), order.Count)
+	assert.Equal(t, "[*wrengo.testOrder, 12.5, wren]\n[book, book]\na-b\n"+
+		"Order is empty.\n"+
+		"Cannot convert 1.5 to int.\n"+
+		"*wrengo.testOrder has no method 'Missing'.\n"+
+		"wrengo.testOrder has no field 'items'.\n"+
+		"Cannot convert string to float64.\n"+
+		"Cannot convert -1 to uint.\n"+
+		"Cannot convert 300 to int8.\n", out.String())
+}
+
+func TestGoObjectNil(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	fn := getFn(t, &vm, `Fn.new {|order|
+		System.print(order.type)
+		System.print(Fiber.new { order["Total"] }.try())
+		System.print(Fiber.new { order["Total"] = 1 }.try())
+	}`)
+	defer fn.Release()
+
+	call := vm.NewCallHandle("call(_)")
+	defer call.Release()
+	vm.EnsureSlots(2)
+	vm.SetSlotHandle(0, fn)
+	assert.NoError(t, vm.SetSlotGoObject(1, (*testOrder)(nil)))
+	assert.NoError(t, call.Call())
+	assert.Equal(t, "*wrengo.testOrder\n"+
+		"Cannot access a field of a nil object.\n"+
+		"Cannot access a field of a nil object.\n", out.String())
+}
@@ -0,0 +1,57 @@
+package wrengo
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// Returned by [enter] when the VM isn't guarded.
+func leaveNothing() {}
+
+// Marks the current goroutine as being inside the VM until the returned
+// function is called, panicking if another goroutine is inside it already.
+//
+// Does nothing unless [DetectConcurrentUse] was set in the configuration.
+func (vm *VM) enter() func() {
+	s := vm.state
+	if !s.guard {
+		return leaveNothing
+	}
+
+	id := goroutineID()
+	for !atomic.CompareAndSwapInt64(&s.owner, 0, id) {
+		owner := atomic.LoadInt64(&s.owner)
+		if owner == id {
+			break
+		}
+		if owner != 0 {
+			panic(fmt.Sprintf("wrengo: VM entered from goroutine %d while goroutine %d is inside it", id, owner))
+		}
+	}
+	s.depth++
+
+	return func() {
+		s.depth--
+		if s.depth == 0 {
+			atomic.StoreInt64(&s.owner, 0)
+		}
+	}
+}
+
+// Returns the ID of the current goroutine, parsed from the header of its
+// stack trace, "goroutine 42 [running]:".
+func goroutineID() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b = b[:bytes.IndexByte(b, ' ')]
+
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		panic("wrengo: cannot parse goroutine ID: " + err.Error())
+	}
+	return id
+}
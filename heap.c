@@ -0,0 +1,81 @@
+#include <stddef.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "wren.h"
+#include "heap.h"
+
+// Every block handed to Wren is prefixed with the heap it was allocated for
+// and its size, so it can be accounted to the right VM when it is resized or
+// freed, no matter which thread that happens on.
+typedef union
+{
+  struct
+  {
+    wrengoHeap* heap;
+    size_t size;
+  } header;
+  max_align_t align;
+} wrengoBlock;
+
+// The heap of the VM the current thread is running, set only for the duration
+// of calls into it.
+static _Thread_local wrengoHeap* currentHeap = NULL;
+
+void* wrengoReallocate(void* memory, size_t newSize)
+{
+  wrengoBlock* block = memory == NULL ? NULL : (wrengoBlock*)memory - 1;
+  wrengoHeap* heap = block == NULL ? currentHeap : block->header.heap;
+  size_t oldSize = block == NULL ? 0 : block->header.size;
+
+  if (newSize == 0)
+  {
+    if (heap != NULL) heap->bytes -= oldSize;
+    free(block);
+    return NULL;
+  }
+
+  block = (wrengoBlock*)realloc(block, sizeof(wrengoBlock) + newSize);
+  if (block == NULL) return NULL;
+
+  block->header.heap = heap;
+  block->header.size = newSize;
+  if (heap != NULL) heap->bytes += newSize - oldSize;
+  return block + 1;
+}
+
+char* wrengoStrdup(const char* text)
+{
+  size_t length = strlen(text) + 1;
+  char* copy = (char*)wrengoReallocate(NULL, length);
+  if (copy != NULL) memcpy(copy, text, length);
+  return copy;
+}
+
+WrenVM* wrengoNewVM(wrengoHeap* heap, WrenConfiguration* config)
+{
+  wrengoHeap* prev = currentHeap;
+  currentHeap = heap;
+  WrenVM* vm = wrenNewVM(config);
+  currentHeap = prev;
+  return vm;
+}
+
+WrenInterpretResult wrengoInterpret(wrengoHeap* heap, WrenVM* vm,
+                                    const char* module, const char* source)
+{
+  wrengoHeap* prev = currentHeap;
+  currentHeap = heap;
+  WrenInterpretResult result = wrenInterpret(vm, module, source);
+  currentHeap = prev;
+  return result;
+}
+
+WrenInterpretResult wrengoCall(wrengoHeap* heap, WrenVM* vm, WrenHandle* method)
+{
+  wrengoHeap* prev = currentHeap;
+  currentHeap = heap;
+  WrenInterpretResult result = wrenCall(vm, method);
+  currentHeap = prev;
+  return result;
+}
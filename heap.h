@@ -0,0 +1,27 @@
+#ifndef wrengo_heap_h
+#define wrengo_heap_h
+
+#include <stddef.h>
+
+#include "wren.h"
+
+// Bytes a VM has allocated while running, see [VM.BytesAllocated].
+typedef struct
+{
+  size_t bytes;
+} wrengoHeap;
+
+// The reallocate function of every VM, accounting memory to its heap.
+void* wrengoReallocate(void* memory, size_t newSize);
+
+// Copies [text] into memory Wren can take ownership of.
+char* wrengoStrdup(const char* text);
+
+// Versions of the Wren functions which account memory allocated while they
+// run to [heap].
+WrenVM* wrengoNewVM(wrengoHeap* heap, WrenConfiguration* config);
+WrenInterpretResult wrengoInterpret(wrengoHeap* heap, WrenVM* vm,
+                                    const char* module, const char* source);
+WrenInterpretResult wrengoCall(wrengoHeap* heap, WrenVM* vm, WrenHandle* method);
+
+#endif
@@ -0,0 +1,212 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Configures the built-in "http" module. Scripts can only use the module when
+// the configuration has one.
+type HTTPConfig struct {
+	// The client requests are sent with, and its transport.
+	//
+	// If this is `NULL`, [http.DefaultClient] is used.
+	Client *http.Client
+
+	// URLs scripts may request, including the ones below them:
+	// "https://api.example.com/v1" allows "https://api.example.com/v1/users"
+	// but not "https://api.example.com/v2". Redirects are checked as well.
+	//
+	// If this is empty, every request is refused.
+	AllowedURLs []string
+}
+
+// The built-in "http" module, enabled by the [HTTP] of the configuration.
+//
+// Requests suspend the calling fiber until the response arrives, see
+// [VM.RunLoop]:
+//
+//	import "http" for Http
+//
+//	var response = Http.get("https://api.example.com/v1/status")
+//	System.print([response.status, response.headers["Content-Type"]])
+//
+//	response = Http.request("POST", "https://api.example.com/v1/users",
+//	    {"Content-Type": "application/json"}, "{\"name\": \"wren\"}")
+//	if (!response.ok) Fiber.abort(response.body)
+//
+// Refused URLs and failed requests abort the fiber. Responses with an error
+// status don't.
+var httpModule = &Module{
+	Name: "http",
+	Source: `
+import "scheduler" for Scheduler
+
+class Http {
+  static get(url) { request("GET", url, {}, null) }
+
+  static request(method, url, headers, body) {
+    if (!(method is String)) Fiber.abort("Method must be a string.")
+    if (!(url is String)) Fiber.abort("URL must be a string.")
+    if (!(headers is Map)) Fiber.abort("Headers must be a map.")
+    if (body != null && !(body is String)) Fiber.abort("Body must be a string.")
+
+    var pairs = []
+    for (name in headers.keys) pairs.add([name.toString, headers[name].toString])
+
+    request_(method, url, pairs, body, Fiber.current)
+    var response = Scheduler.runNextScheduled_()
+    return Response.new_(response[0], response[1], response[2])
+  }
+
+  foreign static request_(method, url, headers, body, fiber)
+}
+
+class Response {
+  construct new_(status, headers, body) {
+    _status = status
+    _headers = {}
+    for (pair in headers) _headers[pair[0]] = pair[1]
+    _body = body
+  }
+
+  status { _status }
+  ok { _status >= 200 && _status < 300 }
+
+  // Keyed by canonical name, like "Content-Type", with repeated headers joined
+  // by commas.
+  headers { _headers }
+  body { _body }
+
+  toString { "Response(%(_status))" }
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Http.request_(_,_,_,_,_)": httpRequest,
+	},
+}
+
+// The HTTP client of a VM, as set up by its [HTTPConfig].
+type httpClient struct {
+	client  *http.Client
+	allowed []*url.URL
+}
+
+func newHTTPClient(config HTTPConfig) *httpClient {
+	c := &httpClient{}
+	for _, s := range config.AllowedURLs {
+		// Invalid entries never match.
+		if u, err := url.Parse(s); err == nil && u.Host != "" {
+			c.allowed = append(c.allowed, u)
+		}
+	}
+
+	client := http.DefaultClient
+	if config.Client != nil {
+		client = config.Client
+	}
+	copied := *client
+	copied.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !c.allows(req.URL) {
+			return fmt.Errorf("redirect to %s is not allowed", req.URL.Redacted())
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	c.client = &copied
+	return c
+}
+
+// Reports whether [u] is one of the allowed URLs or below one.
+func (c *httpClient) allows(u *url.URL) bool {
+	p := path.Clean("/" + u.Path)
+	for _, a := range c.allowed {
+		if u.Scheme != a.Scheme || !strings.EqualFold(u.Host, a.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(a.Path, "/")
+		if prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func httpRequest(vm *VM) {
+	var (
+		method = vm.GetSlotString(1)
+		target = vm.GetSlotString(2)
+		body   []byte
+	)
+
+	u, err := url.Parse(target)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	if strings.Contains("/"+u.Path+"/", "/../") {
+		vm.abortFiber("URL must not contain '..' segments.")
+		return
+	}
+	if !vm.http.allows(u) {
+		vm.abortFiber(fmt.Sprintf("URL %s is not allowed.", u.Redacted()))
+		return
+	}
+
+	headers, err := vm.GetSlotValue(3)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	if vm.GetSlotType(4) == WREN_TYPE_STRING {
+		body = vm.GetSlotBytes(4, 0)
+	}
+
+	client := vm.http.client
+	vm.Async(5, func(ctx context.Context) (interface{}, error) {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range headers.([]interface{}) {
+			pair := pair.([]interface{})
+			req.Header.Add(pair[0].(string), pair[1].(string))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{resp.StatusCode, httpHeaders(resp.Header), data}, nil
+	})
+	vm.SetSlotNull(0)
+}
+
+// Flattens [h] into sorted name and value pairs.
+func httpHeaders(h http.Header) [][]string {
+	pairs := make([][]string, 0, len(h))
+	for name, values := range h {
+		pairs = append(pairs, []string{name, strings.Join(values, ", ")})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return pairs
+}
@@ -0,0 +1,249 @@
+// Package http serves Wren scripts as [http.Handler]s.
+//
+// Every script handles the requests for its own path, by defining a function
+// taking the request and the response:
+//
+//	var handle = Fn.new {|request, response|
+//	  response.header("Content-Type", "text/plain")
+//	  System.print("Hello, %(request.query("name"))!")
+//	}
+//
+// Text the script prints, and a string its function returns, become the body
+// of the response.
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Terisback/wrengo"
+)
+
+// Describes how a [Handler] runs scripts.
+type Config struct {
+	// The scripts requests are routed to by their path: "/users/list" runs
+	// "users/list.wren", and "/" runs "index.wren". Scripts import each other
+	// by the same names.
+	Scripts fs.FS
+
+	// Describes the VMs scripts run in. Its [LoadModuleFunc] is replaced by
+	// one loading modules from [Scripts].
+	Pool wrengo.PoolConfig
+
+	// How long a request may wait for a VM and for its script to run. Scripts
+	// that time out get their VM discarded, once they stop running.
+	//
+	// If zero, requests have no time limit.
+	Timeout time.Duration
+
+	// The largest request body scripts are given.
+	//
+	// If zero, defaults to 1 MiB.
+	MaxBodySize int64
+}
+
+// An [http.Handler] running the Wren script of the path of every request.
+type Handler struct {
+	config Config
+	pool   *wrengo.Pool
+}
+
+// Creates a handler running scripts as described by [config]. It must be
+// closed with [Close] when no longer needed.
+func NewHandler(config Config) *Handler {
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = 1 << 20
+	}
+
+	h := &Handler{config: config}
+
+	pool := config.Pool
+	pool.Configuration.LoadModuleFunc = h.loadModule
+	pool.Bind = func(vm *wrengo.VM) error {
+		if err := vm.RegisterModule(serverModule); err != nil {
+			return err
+		}
+		// Loaded up front, so its classes can be read from Go.
+		if err := vm.Interpret(serverModule.Name+":init", `import "wrengo/http"`); err != nil {
+			return err
+		}
+		if config.Pool.Bind != nil {
+			return config.Pool.Bind(vm)
+		}
+		return nil
+	}
+	h.pool = wrengo.NewPool(pool)
+	return h
+}
+
+// Frees the VMs of the handler.
+func (h *Handler) Close() {
+	h.pool.Close()
+}
+
+func (h *Handler) loadModule(vm *wrengo.VM, name string) string {
+	source, err := fs.ReadFile(h.config.Scripts, name+".wren")
+	if err != nil {
+		return ""
+	}
+	return string(source)
+}
+
+// Returns the module handling the requests for [p], reporting whether it is a
+// valid module name. Names are limited to letters, digits, '_', '-' and '/',
+// since they are spliced into Wren source.
+func route(p string) (string, bool) {
+	module := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if module == "" {
+		return "index", true
+	}
+	for _, c := range module {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("_-/", c)) {
+			return "", false
+		}
+	}
+	return module, true
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	module, ok := route(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, err := fs.Stat(h.config.Scripts, module+".wren"); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.config.MaxBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	ctx := r.Context()
+	if h.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.config.Timeout)
+		defer cancel()
+	}
+
+	vm, err := h.pool.Get(ctx)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	// The script may outlive the request when it times out, so it only gets
+	// copies of it.
+	req := &request{
+		method: r.Method,
+		path:   r.URL.Path,
+		header: r.Header.Clone(),
+		query:  r.URL.Query(),
+		body:   body,
+	}
+	resp := &response{status: http.StatusOK, header: make(http.Header)}
+	done := make(chan error, 1)
+	go func() {
+		done <- vm.WithOutput(&resp.body, func() error {
+			if err := serve(vm, module, req, resp); err != nil {
+				return err
+			}
+			return vm.RunLoop(ctx)
+		})
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// A script busy in Wren can't be interrupted, so its VM is discarded
+		// once it gives up.
+		go func() {
+			<-done
+			h.pool.Discard(vm)
+		}()
+		http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+		return
+	}
+
+	if err != nil {
+		// The VM may be left with suspended fibers, or half loaded modules.
+		h.pool.Discard(vm)
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.pool.Put(vm)
+
+	for name, values := range resp.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.status)
+	w.Write(resp.body.Bytes())
+}
+
+// Calls the function of [module] with the request and response, loading the
+// module first if the VM hasn't yet.
+func serve(vm *wrengo.VM, module string, req *request, resp *response) error {
+	vm.EnsureSlots(3)
+	vm.GetVariable(serverModule.Name, "Request", 0)
+	vm.SetSlotNewObject(1, 0, req)
+	vm.GetVariable(serverModule.Name, "Response", 0)
+	vm.SetSlotNewObject(2, 0, resp)
+	request, response := vm.GetSlotHandle(1), vm.GetSlotHandle(2)
+	defer request.Release()
+	defer response.Release()
+
+	// Functions are called through call handles the VM keeps.
+	vm.GetVariable(serverModule.Name, "Dispatch", 0)
+	dispatch, err := vm.GetSlotFn(0)
+	if err != nil {
+		return err
+	}
+	defer dispatch.Release()
+
+	served, err := dispatch.Call(module, request, response)
+	if err != nil || served == true {
+		return err
+	}
+
+	source := `
+import "wrengo/http" for Server
+import "` + module + `" for handle
+Server.register("` + module + `", handle)
+`
+	if err := vm.Interpret(serverModule.Name+":"+module, source); err != nil {
+		return err
+	}
+	_, err = dispatch.Call(module, request, response)
+	return err
+}
+
+// The Go value of a Request.
+type request struct {
+	method string
+	path   string
+	header http.Header
+	query  url.Values
+	body   []byte
+}
+
+// The Go value of a Response.
+type response struct {
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Terisback/wrengo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	config := wrengo.NewConfiguration()
+
+	handler := NewHandler(Config{
+		Scripts: fstest.MapFS{
+			"index.wren": {Data: []byte(`
+				var handle = Fn.new {|request, response| "index" }
+			`)},
+			"greet.wren": {Data: []byte(`
+				import "greeting" for Greeting
+				var handle = Fn.new {|request, response|
+				  response.status = 201
+				  response.header("X-Method", request.method)
+				  System.print(Greeting.hello(request.query("name")))
+				  return "%(request.header("X-Token")):%(request.body)"
+				}
+			`)},
+			"greeting.wren": {Data: []byte(`
+				class Greeting {
+				  static hello(name) { "Hello, %(name)!" }
+				}
+			`)},
+			"sleep.wren": {Data: []byte(`
+				import "timer" for Timer
+				var handle = Fn.new {|request, response|
+				  Timer.sleep(1000)
+				  response.write("woke up")
+				}
+			`)},
+			"busy.wren": {Data: []byte(`
+				var handle = Fn.new {|request, response|
+				  var i = 0
+				  while (i < 100000000) i = i + 1
+				}
+			`)},
+			"fail.wren": {Data: []byte(`
+				var handle = Fn.new {|request, response| Fiber.abort("boom") }
+			`)},
+		},
+		Pool:    wrengo.PoolConfig{Configuration: config, MaxSize: 1},
+		Timeout: 50 * time.Millisecond,
+	})
+	defer handler.Close()
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-Token", "secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("GET", "/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "index", rec.Body.String())
+
+	for i := 0; i < 2; i++ {
+		rec = serve("POST", "/greet?name=wren", "body")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "POST", rec.Header().Get("X-Method"))
+		assert.Equal(t, "Hello, wren!\nsecret:body", rec.Body.String())
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/missing", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve("GET", `/gr"eet`, "").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve("GET", "/fail", "").Code)
+	assert.Equal(t, http.StatusGatewayTimeout, serve("GET", "/sleep", "").Code)
+
+	// VMs that failed or timed out are replaced.
+	assert.Equal(t, http.StatusOK, serve("GET", "/", "").Code)
+
+	// Scripts busy in Wren time out too.
+	assert.Equal(t, http.StatusGatewayTimeout, serve("GET", "/busy", "").Code)
+}
+
+func TestRoute(t *testing.T) {
+	for p, module := range map[string]string{
+		"/":            "index",
+		"/users/list":  "users/list",
+		"/a/../b":      "b",
+		"/../../etc/x": "etc/x",
+	} {
+		got, ok := route(p)
+		assert.True(t, ok)
+		assert.Equal(t, module, got)
+	}
+
+	_, ok := route("/x.wren")
+	assert.False(t, ok)
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/Terisback/wrengo"
+)
+
+// The module requests and responses are defined in, which keeps the function
+// of every script loaded in the VM.
+var serverModule = &wrengo.Module{
+	Name: "wrengo/http",
+	Source: `
+class Server {
+  static handler(module) {
+    if (__handlers == null) __handlers = {}
+    return __handlers[module]
+  }
+
+  static register(module, handler) {
+    if (!(handler is Fn) || handler.arity != 2) {
+      Fiber.abort("The handle of %(module) must be a function taking the request and the response.")
+    }
+    __handlers[module] = handler
+  }
+
+  static serve(handler, request, response) {
+    var result = handler.call(request, response)
+    if (result is String) response.write(result)
+  }
+}
+
+// Serves a request with the function of [module], or returns false if the
+// module isn't loaded yet.
+var Dispatch = Fn.new {|module, request, response|
+  var handler = Server.handler(module)
+  if (handler == null) return false
+  Server.serve(handler, request, response)
+  return true
+}
+
+foreign class Request {
+  foreign method
+  foreign path
+  foreign body
+  foreign header(name)
+  foreign query(name)
+}
+
+foreign class Response {
+  foreign status
+  foreign status=(value)
+  foreign header(name, value)
+  foreign write(text)
+}
+`,
+	Methods: map[string]func(*wrengo.VM){
+		"Request.method":       func(vm *wrengo.VM) { vm.SetSlotString(0, requestOf(vm).method) },
+		"Request.path":         func(vm *wrengo.VM) { vm.SetSlotString(0, requestOf(vm).path) },
+		"Request.body":         func(vm *wrengo.VM) { vm.SetSlotBytes(0, requestOf(vm).body) },
+		"Request.header(_)":    requestHeader,
+		"Request.query(_)":     requestQuery,
+		"Response.status":      func(vm *wrengo.VM) { vm.SetSlotDouble(0, float64(responseOf(vm).status)) },
+		"Response.status=(_)":  responseSetStatus,
+		"Response.header(_,_)": responseHeader,
+		"Response.write(_)":    responseWrite,
+	},
+	Classes: map[string]func(*wrengo.VM) interface{}{
+		// Instances are only created by the handler.
+		"Request":  func(*wrengo.VM) interface{} { return nil },
+		"Response": func(*wrengo.VM) interface{} { return nil },
+	},
+}
+
+func requestOf(vm *wrengo.VM) *request {
+	return vm.GetSlotObject(0).(*request)
+}
+
+func responseOf(vm *wrengo.VM) *response {
+	return vm.GetSlotObject(0).(*response)
+}
+
+// Reads the string in [slot], or aborts the fiber if there is something else.
+func slotString(vm *wrengo.VM, slot int, name string) (string, bool) {
+	if vm.GetSlotType(slot) != wrengo.WREN_TYPE_STRING {
+		vm.SetSlotString(0, name+" must be a string.")
+		vm.AbortFiber(0)
+		return "", false
+	}
+	return vm.GetSlotString(slot), true
+}
+
+// Sets slot 0 to [value], or null if it is empty.
+func setSlotOptional(vm *wrengo.VM, value string, ok bool) {
+	if !ok {
+		vm.SetSlotNull(0)
+		return
+	}
+	vm.SetSlotString(0, value)
+}
+
+func requestHeader(vm *wrengo.VM) {
+	name, ok := slotString(vm, 1, "Name")
+	if !ok {
+		return
+	}
+	values := requestOf(vm).header.Values(name)
+	setSlotOptional(vm, strings.Join(values, ", "), len(values) > 0)
+}
+
+func requestQuery(vm *wrengo.VM) {
+	name, ok := slotString(vm, 1, "Name")
+	if !ok {
+		return
+	}
+	values, ok := requestOf(vm).query[name]
+	setSlotOptional(vm, strings.Join(values, ","), ok)
+}
+
+func responseSetStatus(vm *wrengo.VM) {
+	if vm.GetSlotType(1) != wrengo.WREN_TYPE_NUM {
+		vm.SetSlotString(0, "Status must be a number.")
+		vm.AbortFiber(0)
+		return
+	}
+	status := int(vm.GetSlotDouble(1))
+	if status < 100 || status > 999 {
+		vm.SetSlotString(0, "Status must be between 100 and 999.")
+		vm.AbortFiber(0)
+		return
+	}
+	responseOf(vm).status = status
+	vm.SetSlotDouble(0, float64(status))
+}
+
+func responseHeader(vm *wrengo.VM) {
+	name, ok := slotString(vm, 1, "Name")
+	if !ok {
+		return
+	}
+	value, ok := slotString(vm, 2, "Value")
+	if !ok {
+		return
+	}
+	responseOf(vm).header.Add(name, value)
+	vm.SetSlotNull(0)
+}
+
+func responseWrite(vm *wrengo.VM) {
+	if vm.GetSlotType(1) != wrengo.WREN_TYPE_STRING {
+		vm.SetSlotString(0, "Text must be a string.")
+		vm.AbortFiber(0)
+		return
+	}
+	responseOf(vm).body.Write(vm.GetSlotBytes(1, 0))
+	vm.SetSlotNull(0)
+}
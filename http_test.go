@@ -0,0 +1,67 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("X-Method", r.Method)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(r.Header.Get("X-Token") + ":" + string(body)))
+		case "/api/redirect":
+			http.Redirect(w, r, "/private", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.HTTP = &HTTPConfig{
+		Client:      server.Client(),
+		AllowedURLs: []string{server.URL + "/api"},
+	}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "http" for Http
+		var response = Http.request("POST", "`+server.URL+`/api/echo", {"X-Token": "secret"}, "hello")
+		System.print([response.status, response.ok, response.headers["X-Method"], response.body])
+
+		response = Http.get("`+server.URL+`/api/missing")
+		System.print([response.status, response.ok])
+	`))
+	assert.NoError(t, vm.RunLoop(context.Background()))
+	assert.Equal(t, "[201, true, POST, secret:hello]\n[404, false]\n", out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Http.get("`+server.URL+`/private")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Http.get("`+server.URL+`/api/../private")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Http.get("`+server.URL+`/apiary")`))
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `Http.get("`+server.URL+`/api/redirect")`))
+	assert.Error(t, vm.RunLoop(context.Background()))
+}
+
+func TestHTTPDisabled(t *testing.T) {
+	config := NewConfiguration()
+	config.Stderr = &bytes.Buffer{}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.Error(t, vm.Interpret(DefaultModule, `import "http" for Http`))
+}
@@ -0,0 +1,261 @@
+package wrengo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var handleType = reflect.TypeOf(Handle{})
+
+// Returns a [T] calling the methods of the Wren object held by [object], so
+// Wren objects can stand in for Go ones, like plugins:
+//
+//	//go:generate go run github.com/Terisback/wrengo/cmd/wrenimpl -type Plugin
+//
+//	type Plugin interface {
+//	  Name() (string, error)
+//	  Handle(event map[string]interface{}) (string, error)
+//	}
+//
+//	plugin, err := wrengo.Implement[Plugin](vm, object)
+//
+// Every method must return an error last, which receives the runtime errors of
+// the Wren method and the failures to convert its result, so scripts can't
+// crash the host. [ImplementPanicking] accepts methods without one.
+//
+// Go can't create types with methods at runtime, so an interface can only be
+// implemented once the adapter forwarding its methods is registered with
+// [RegisterAdapter], which is the code wrenimpl generates. [T] may also be a
+// struct of functions, which is filled directly.
+//
+// Methods call the Wren method named after them with the first letter
+// lowered, as a getter if they take no arguments: Run calls `run(_)` if it
+// takes one argument, and Name calls `name`. Fields of a struct may give
+// another signature in their `wren` tag.
+//
+// Arguments are converted as by [SetSlotValue], except that maps are supported
+// too. Results are converted to the type the method returns, a [Handle]
+// holding the object itself if it returns one.
+//
+// The handle to the object is not released, and must outlive [T]. The methods
+// must not be called from a foreign method, nor once the VM is freed.
+func Implement[T any](vm *VM, object Handle) (T, error) {
+	return implement[T](vm, object, false)
+}
+
+// Like [Implement], but [T] may have methods returning no error.
+//
+// Those methods PANIC when the Wren method hits a runtime error or its result
+// can't be converted, so a bug in a script crashes the host unless the caller
+// recovers.
+func ImplementPanicking[T any](vm *VM, object Handle) (T, error) {
+	return implement[T](vm, object, true)
+}
+
+func implement[T any](vm *VM, object Handle, panics bool) (T, error) {
+	var impl T
+
+	t := reflect.TypeOf(&impl).Elem()
+	if t.Kind() == reflect.Interface {
+		adaptersGuard.RLock()
+		a, ok := adapters[t]
+		adaptersGuard.RUnlock()
+		if !ok {
+			return impl, fmt.Errorf("wrengo: no adapter registered for %s, generate one with wrenimpl", t)
+		}
+
+		funcs, err := vm.implementFuncs(object, a.funcs, panics)
+		if err != nil {
+			return impl, err
+		}
+		impl = a.adapt.Call([]reflect.Value{funcs})[0].Interface().(T)
+		return impl, nil
+	}
+
+	funcs, err := vm.implementFuncs(object, t, panics)
+	if err != nil {
+		return impl, err
+	}
+	return funcs.Interface().(T), nil
+}
+
+// Adapters registered with [RegisterAdapter], by the interface they return.
+var (
+	adapters      = make(map[reflect.Type]adapter)
+	adaptersGuard sync.RWMutex
+)
+
+type adapter struct {
+	funcs reflect.Type
+	adapt reflect.Value
+}
+
+// Lets [Implement] return the interface [T], by filling the struct of
+// functions [F] and passing it to [adapt]. It is called by the code wrenimpl
+// generates, from an init function.
+func RegisterAdapter[T, F any](adapt func(F) T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("wrengo: cannot register an adapter for %s, only interfaces", t))
+	}
+
+	adaptersGuard.Lock()
+	defer adaptersGuard.Unlock()
+	adapters[t] = adapter{
+		funcs: reflect.TypeOf((*F)(nil)).Elem(),
+		adapt: reflect.ValueOf(adapt),
+	}
+}
+
+// Returns a new struct of type [t] whose function fields call the methods of
+// [object]. Functions returning no error are only accepted if they may
+// [panic].
+func (vm *VM) implementFuncs(object Handle, t reflect.Type, panics bool) (reflect.Value, error) {
+	if t.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("wrengo: cannot implement %s, only interfaces and structs of functions", t)
+	}
+	if err := vm.require(valueModule); err != nil {
+		return reflect.Value{}, err
+	}
+
+	v := reflect.New(t).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if err := implementable(field.Type, panics); err != nil {
+			return reflect.Value{}, fmt.Errorf("wrengo: cannot implement field %s: %w", field.Name, err)
+		}
+
+		signature := field.Tag.Get("wren")
+		if signature == "" {
+			signature = methodSignature(field.Name, field.Type.NumIn())
+		}
+		ft := field.Type
+		v.Field(i).Set(reflect.MakeFunc(ft, func(in []reflect.Value) []reflect.Value {
+			return vm.callImplemented(object, signature, ft, in)
+		}))
+	}
+	return v, nil
+}
+
+// Reports why functions of type [t] can't call Wren methods, if they can't.
+// Functions returning no error can if they may [panic].
+func implementable(t reflect.Type, panics bool) error {
+	switch {
+	case t.Kind() != reflect.Func:
+		return fmt.Errorf("%s is not a function", t)
+	case t.IsVariadic():
+		return fmt.Errorf("%s is variadic", t)
+	case t.NumOut() > 2 || t.NumOut() == 2 && t.Out(1) != errorType:
+		return fmt.Errorf("%s must return at most a value and an error", t)
+	case !panics && (t.NumOut() == 0 || t.Out(t.NumOut()-1) != errorType):
+		return fmt.Errorf("%s must return an error, or be implemented with ImplementPanicking", t)
+	}
+	return nil
+}
+
+// Returns the signature of the Wren method implementing the Go function
+// called [name] taking [arity] arguments.
+func methodSignature(name string, arity int) string {
+	name = string(unicode.ToLower(rune(name[0]))) + name[1:]
+	if arity == 0 {
+		return name
+	}
+	return name + "(" + strings.TrimSuffix(strings.Repeat("_,", arity), ",") + ")"
+}
+
+// Calls the method with [signature] on [object] for a function made by
+// [Implement] of type [t], returning the results of the function.
+func (vm *VM) callImplemented(object Handle, signature string, t reflect.Type, in []reflect.Value) []reflect.Value {
+	args := make([]interface{}, len(in))
+	for i, arg := range in {
+		args[i] = arg.Interface()
+	}
+
+	out := make([]reflect.Value, t.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(t.Out(i))
+	}
+	returnsErr := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	returnsValue := t.NumOut() > 0 && !(t.NumOut() == 1 && returnsErr)
+
+	result, err := vm.callMethod(object, signature, args, returnsValue && t.Out(0) == handleType)
+	if err == nil && returnsValue {
+		var v reflect.Value
+		if v, err = goConvert(result, t.Out(0)); err == nil {
+			out[0] = v
+		}
+	}
+
+	if err != nil {
+		if !returnsErr {
+			panic(err)
+		}
+		out[len(out)-1] = reflect.ValueOf(&err).Elem()
+	}
+	return out
+}
+
+// Calls the method with [signature] on the object held by [receiver], passing
+// [args] converted as by [SetSlotValue], except that maps are supported too.
+//
+// Returns the result converted as by [GetSlotValue], with maps becoming
+// map[interface{}]interface{}, or a handle to it if [asHandle].
+func (vm *VM) callMethod(receiver Handle, signature string, args []interface{}, asHandle bool) (interface{}, error) {
+	if err := vm.require(valueModule); err != nil {
+		return nil, err
+	}
+
+	// Lists and maps are set up by the value module, so arguments are decoded
+	// by it one at a time first.
+	decode := vm.callHandle("decode(_)")
+	handles := make([]Handle, len(args))
+	for i, arg := range args {
+		vm.EnsureSlots(2)
+		vm.GetVariable(valueModule.Name, "Value", 0)
+		err := vm.setSlotEncoded(1, arg)
+		if err == nil {
+			err = decode.Call()
+		}
+		if err != nil {
+			for _, h := range handles[:i] {
+				h.Release()
+			}
+			return nil, err
+		}
+		handles[i] = vm.GetSlotHandle(0)
+	}
+
+	vm.EnsureSlots(len(args) + 1)
+	vm.SetSlotHandle(0, receiver)
+	for i, h := range handles {
+		vm.SetSlotHandle(i+1, h)
+		h.Release()
+	}
+	if err := vm.callHandle(signature).Call(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case asHandle:
+		return vm.GetSlotHandle(0), nil
+	case vm.GetSlotType(0) != WREN_TYPE_LIST && vm.GetSlotType(0) != WREN_TYPE_UNKNOWN:
+		return vm.GetSlotValue(0)
+	}
+
+	// Maps can't be read from slots, so the result is encoded first.
+	result := vm.GetSlotHandle(0)
+	defer result.Release()
+	vm.EnsureSlots(2)
+	vm.GetVariable(valueModule.Name, "Value", 0)
+	vm.SetSlotHandle(1, result)
+	if err := vm.callHandle("encode(_)").Call(); err != nil {
+		return nil, err
+	}
+	return vm.getSlotEncoded(0)
+}
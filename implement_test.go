@@ -0,0 +1,79 @@
+package wrengo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate go run ./cmd/wrenimpl -type testPlugin
+
+type testPlugin interface {
+	Name() string
+	Handle(event map[string]interface{}) (string, error)
+	Counts(names []string) (map[string]int, error)
+	Self() Handle
+}
+
+type testCounter struct {
+	Count func(names []string) (map[string]int, error) `wren:"counts(_)"`
+}
+
+func TestImplement(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		class Greeter {
+			construct new() {}
+			name { "greeter" }
+			handle(event) {
+				if (event["kind"] != "join") Fiber.abort("Unknown event.")
+				return "Welcome, %(event["player"])!"
+			}
+			counts(names) {
+				var counts = {}
+				for (name in names) counts[name] = (counts[name] || 0) + 1
+				return counts
+			}
+			self { this }
+		}
+		var greeter = Greeter.new()
+	`))
+	vm.EnsureSlots(1)
+	vm.GetVariable(DefaultModule, "greeter", 0)
+	object := vm.GetSlotHandle(0)
+	defer object.Release()
+
+	_, err := Implement[testPlugin](&vm, object)
+	assert.Error(t, err)
+
+	plugin, err := ImplementPanicking[testPlugin](&vm, object)
+	assert.NoError(t, err)
+	assert.Equal(t, "greeter", plugin.Name())
+
+	greeting, err := plugin.Handle(map[string]interface{}{"kind": "join", "player": "wren"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome, wren!", greeting)
+
+	_, err = plugin.Handle(map[string]interface{}{"kind": "leave"})
+	assert.Error(t, err)
+
+	counts, err := plugin.Counts([]string{"a", "b", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, counts)
+
+	self := plugin.Self()
+	self.Release()
+
+	counter, err := Implement[testCounter](&vm, object)
+	assert.NoError(t, err)
+	counts, err = counter.Count([]string{"b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"b": 1}, counts)
+
+	_, err = Implement[interface{ Run() }](&vm, object)
+	assert.Error(t, err)
+	_, err = Implement[struct{ Run func(...int) }](&vm, object)
+	assert.Error(t, err)
+}
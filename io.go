@@ -0,0 +1,270 @@
+package wrengo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// Configures the built-in "io" module. Scripts can only use the module when
+// the configuration has one.
+type IOConfig struct {
+	// The directory every path is resolved in. Paths referencing a location
+	// outside of it, like "../a.txt" or symbolic links, are refused. It is
+	// opened once, the first time a script uses a file.
+	//
+	// If both this and [FS] are empty, paths are used as they are.
+	Root string
+
+	// The file system every path is resolved in, instead of [Root]. Files can
+	// only be read from it.
+	FS fs.FS
+
+	// Refuse to write files.
+	ReadOnly bool
+
+	// Where `Stdin.readLine()` reads from.
+	//
+	// If this is `NULL`, [os.Stdin] is used.
+	Stdin io.Reader
+}
+
+// The built-in "io" module, enabled by the [IO] of the configuration:
+//
+//	import "io" for File, Directory, Stdin
+//
+//	File.write("hello.txt", "Hello!")
+//	System.print(File.read("hello.txt"))
+//	System.print(Directory.list("."))
+//	var line = Stdin.readLine()
+//
+// Failures abort the calling fiber with the error.
+var ioModule = &Module{
+	Name: "io",
+	Source: `
+class File {
+  static read(path) { read_(validate_(path)) }
+
+  static write(path, data) {
+    if (!(data is String)) Fiber.abort("Data must be a string.")
+    return write_(validate_(path), data)
+  }
+
+  static exists(path) { exists_(validate_(path)) }
+
+  static validate_(path) {
+    if (!(path is String)) Fiber.abort("Path must be a string.")
+    return path
+  }
+
+  foreign static read_(path)
+  foreign static write_(path, data)
+  foreign static exists_(path)
+}
+
+class Directory {
+  static list(path) { list_(File.validate_(path)) }
+
+  foreign static list_(path)
+}
+
+class Stdin {
+  foreign static readLine()
+}
+`,
+	Methods: map[string]func(*VM){
+		"static File.read_(_)":      ioFileRead,
+		"static File.write_(_,_)":   ioFileWrite,
+		"static File.exists_(_)":    ioFileExists,
+		"static Directory.list_(_)": ioDirectoryList,
+		"static Stdin.readLine()":   ioStdinReadLine,
+	},
+}
+
+// The files of a VM, as set up by its [IOConfig].
+type files struct {
+	config IOConfig
+	stdin  *bufio.Reader
+
+	// The directory of [IOConfig.Root], opened once used.
+	root    *os.Root
+	rootErr error
+}
+
+func newFiles(config IOConfig) *files {
+	stdin := config.Stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	return &files{config: config, stdin: bufio.NewReader(stdin)}
+}
+
+// Returns the root directory, opening it the first time.
+func (f *files) openRoot() (*os.Root, error) {
+	if f.root == nil && f.rootErr == nil {
+		f.root, f.rootErr = os.OpenRoot(f.config.Root)
+	}
+	return f.root, f.rootErr
+}
+
+// Closes the root directory, if it was opened.
+func (f *files) close() {
+	if f.root != nil {
+		f.root.Close()
+	}
+}
+
+// Turns a path given by a script into one of an [fs.FS], which never leaves
+// the root: "/data/../a.txt" becomes "a.txt".
+func fsPath(name string) string {
+	name = path.Clean("/" + strings.ReplaceAll(name, "\\", "/"))
+	if name == "/" {
+		return "."
+	}
+	return name[1:]
+}
+
+// Turns a path given by a script into one of an [fs.FS] like [fsPath], but
+// refuses paths leaving the root, like "../a.txt", instead.
+func rootPath(name string) (string, error) {
+	clean := path.Clean(strings.TrimLeft(strings.ReplaceAll(name, "\\", "/"), "/"))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("path %s is outside of the root", name)
+	}
+	return clean, nil
+}
+
+func (f *files) readFile(name string) ([]byte, error) {
+	if f.config.FS == nil && f.config.Root == "" {
+		return os.ReadFile(name)
+	}
+
+	fsys, name, err := f.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(fsys, name)
+}
+
+func (f *files) writeFile(name string, data []byte) error {
+	switch {
+	case f.config.ReadOnly || f.config.FS != nil:
+		return errors.New("file system is read-only")
+	case f.config.Root == "":
+		return os.WriteFile(name, data, 0644)
+	}
+
+	name, err := rootPath(name)
+	if err != nil {
+		return err
+	}
+	root, err := f.openRoot()
+	if err != nil {
+		return err
+	}
+
+	file, err := root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *files) stat(name string) (fs.FileInfo, error) {
+	if f.config.FS == nil && f.config.Root == "" {
+		return os.Stat(name)
+	}
+
+	fsys, name, err := f.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(fsys, name)
+}
+
+func (f *files) readDir(name string) ([]fs.DirEntry, error) {
+	if f.config.FS == nil && f.config.Root == "" {
+		return os.ReadDir(name)
+	}
+
+	fsys, name, err := f.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadDir(fsys, name)
+}
+
+// Returns the file system of [FS] or [Root], and [name] within it.
+func (f *files) resolve(name string) (fs.FS, string, error) {
+	name, err := rootPath(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if f.config.FS != nil {
+		return f.config.FS, name, nil
+	}
+
+	root, err := f.openRoot()
+	if err != nil {
+		return nil, "", err
+	}
+	return root.FS(), name, nil
+}
+
+func ioFileRead(vm *VM) {
+	data, err := vm.files.readFile(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotBytes(0, data)
+}
+
+func ioFileWrite(vm *VM) {
+	if err := vm.files.writeFile(vm.GetSlotString(1), vm.GetSlotBytes(2, 0)); err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNull(0)
+}
+
+func ioFileExists(vm *VM) {
+	info, err := vm.files.stat(vm.GetSlotString(1))
+	vm.SetSlotBool(0, err == nil && !info.IsDir())
+}
+
+func ioDirectoryList(vm *VM) {
+	entries, err := vm.files.readDir(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	vm.SetSlotValue(0, names)
+}
+
+func ioStdinReadLine(vm *VM) {
+	line, err := vm.files.stdin.ReadString('\n')
+	switch {
+	case err == io.EOF && line == "":
+		vm.SetSlotNull(0)
+	case err != nil && err != io.EOF:
+		vm.abortFiber(err.Error())
+	default:
+		line = strings.TrimSuffix(line, "\n")
+		vm.SetSlotString(0, strings.TrimSuffix(line, "\r"))
+	}
+}
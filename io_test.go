@@ -0,0 +1,81 @@
+package wrengo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIORoot(t *testing.T) {
+	var out bytes.Buffer
+	dir := t.TempDir()
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.IO = &IOConfig{Root: dir}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "io" for File, Directory
+		File.write("/a.txt", "Hello!")
+		System.print(File.read("/data/../a.txt"))
+		System.print(File.exists("a.txt"))
+		System.print(File.exists("b.txt"))
+		System.print(Directory.list("/"))
+	`))
+	assert.Equal(t, "Hello!\ntrue\nfalse\n[a.txt]\n", out.String())
+
+	data, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello!", string(data))
+
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	assert.NoError(t, os.WriteFile(outside, []byte("secret"), 0644))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(dir, "link.txt")))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.read("../../a.txt")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.write("../b.txt", "data")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.read("link.txt")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.read("missing.txt")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.read(1)`))
+	assert.Error(t, vm.Interpret(DefaultModule, `File.write("a.txt", 3)`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Directory.list(null)`))
+}
+
+func TestIOReadOnly(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.IO = &IOConfig{
+		FS:    fstest.MapFS{"data/a.txt": {Data: []byte("from fs")}},
+		Stdin: strings.NewReader("first\r\nsecond"),
+	}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "io" for File, Directory, Stdin
+		System.print(File.read("data/a.txt"))
+		System.print(Directory.list("data"))
+		System.print(Stdin.readLine())
+		System.print(Stdin.readLine())
+		System.print(Stdin.readLine())
+	`))
+	assert.Equal(t, "from fs\n[a.txt]\nfirst\nsecond\nnull\n", out.String())
+	assert.Error(t, vm.Interpret(DefaultModule, `File.write("b.txt", "data")`))
+}
+
+func TestIODisabled(t *testing.T) {
+	config := NewConfiguration()
+	config.Stderr = &bytes.Buffer{}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.Error(t, vm.Interpret(DefaultModule, `import "io" for File`))
+}
@@ -0,0 +1,120 @@
+package wrengo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The built-in "json" module:
+//
+//	import "json" for JSON
+//
+//	var config = JSON.parse("{\"name\": \"wren\", \"tags\": [1, 2]}")
+//	System.print(config["tags"][0])
+//	System.print(JSON.stringify(config, 2))
+//
+// Objects become maps and arrays become lists. When stringified, keys that
+// aren't strings are converted like `toString` does, and cyclic values abort
+// the fiber. The indentation is either a number of spaces or a string.
+var jsonModule = &Module{
+	Name: "json",
+	Source: `
+import "wrengo/value" for Value
+
+class JSON {
+  static parse(string) {
+    if (!(string is String)) Fiber.abort("JSON must be a string.")
+    return Value.decode(parse_(string))
+  }
+
+  static stringify(value) { stringify(value, "") }
+  static stringify(value, indent) {
+    if (indent is Num) indent = " " * indent
+    if (!(indent is String)) Fiber.abort("Indent must be a number or a string.")
+    return stringify_(Value.encode(value), indent)
+  }
+
+  foreign static parse_(string)
+  foreign static stringify_(value, indent)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static JSON.parse_(_)":       jsonParse,
+		"static JSON.stringify_(_,_)": jsonStringify,
+	},
+}
+
+func jsonParse(vm *VM) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(vm.GetSlotString(1)), &value); err != nil {
+		vm.abortFiber("Invalid JSON: " + err.Error())
+		return
+	}
+	if err := vm.setSlotEncoded(0, value); err != nil {
+		vm.abortFiber(err.Error())
+	}
+}
+
+func jsonStringify(vm *VM) {
+	value, err := vm.getSlotEncoded(1)
+	if err == nil {
+		value, err = jsonValue(value)
+	}
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", vm.GetSlotString(2))
+	if err := enc.Encode(value); err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotString(0, strings.TrimSuffix(buf.String(), "\n"))
+}
+
+// Converts a decoded Wren value into one [json.Marshal] accepts, with the keys
+// of maps turned into strings.
+func jsonValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			element, err := jsonValue(element)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = element
+		}
+		return list, nil
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			element, err := jsonValue(element)
+			if err != nil {
+				return nil, err
+			}
+			object[jsonKey(key)] = element
+		}
+		return object, nil
+	default:
+		return value, nil
+	}
+}
+
+// Converts the key of a map into a string, formatted like Wren does.
+func jsonKey(key interface{}) string {
+	switch k := key.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return fmt.Sprintf("%.14g", k)
+	default:
+		return fmt.Sprint(k)
+	}
+}
@@ -0,0 +1,47 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "json" for JSON
+		var value = JSON.parse("{\"name\": \"wren\", \"tags\": [1, true, null], \"nested\": {}}")
+		System.print(value["name"])
+		System.print(value["tags"])
+		System.print(value["nested"] is Map)
+		System.print(JSON.stringify({1: "one", true: [1.5, "<a>"], null: {}}))
+		System.print(JSON.stringify(["a", {"b": 1}], 2))
+	`))
+	assert.Equal(t, `wren
+[1, true, null]
+true
+{"1":"one","null":{},"true":[1.5,"<a>"]}
+[
+  "a",
+  {
+    "b": 1
+  }
+]
+`, out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `JSON.parse("{")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `
+		var list = [1]
+		list.add(list)
+		JSON.stringify(list)
+	`))
+	assert.Error(t, vm.Interpret(DefaultModule, `JSON.stringify(0/0)`))
+	assert.Error(t, vm.Interpret(DefaultModule, `JSON.stringify(Fn.new {})`))
+}
@@ -0,0 +1,79 @@
+package wrengo
+
+import (
+	"context"
+	"log/slog"
+)
+
+// The built-in "log" module.
+//
+// Forwards messages logged by scripts to the [Logger] of the configuration:
+//
+//	import "log" for Log
+//	Log.info("player joined", {"name": name, "level": 3})
+//
+// Attribute values that are not numbers, booleans, strings or null are logged
+// as their `toString`.
+var logModule = &Module{
+	Name: "log",
+	Source: `
+class Log {
+  static debug(message) { write_(-4, message.toString, []) }
+  static debug(message, attrs) { write_(-4, message.toString, attrs_(attrs)) }
+  static info(message) { write_(0, message.toString, []) }
+  static info(message, attrs) { write_(0, message.toString, attrs_(attrs)) }
+  static warn(message) { write_(4, message.toString, []) }
+  static warn(message, attrs) { write_(4, message.toString, attrs_(attrs)) }
+  static error(message) { write_(8, message.toString, []) }
+  static error(message, attrs) { write_(8, message.toString, attrs_(attrs)) }
+
+  static attrs_(attrs) {
+    var list = []
+    for (key in attrs.keys) {
+      var value = attrs[key]
+      if (!(value is Num || value is Bool || value is String || value == null)) {
+        value = value.toString
+      }
+      list.add(key.toString)
+      list.add(value)
+    }
+    return list
+  }
+
+  foreign static write_(level, message, attrs)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Log.write_(_,_,_)": logWrite,
+	},
+}
+
+func logWrite(vm *VM) {
+	var (
+		level   = slog.Level(vm.GetSlotDouble(1))
+		message = vm.GetSlotString(2)
+		count   = vm.GetListCount(3)
+		attrs   = make([]slog.Attr, 0, count/2)
+	)
+
+	vm.EnsureSlots(5)
+	for i := 0; i+1 < count; i += 2 {
+		vm.GetListElement(3, i, 4)
+		key := vm.GetSlotString(4)
+		vm.GetListElement(3, i+1, 4)
+
+		switch vm.GetSlotType(4) {
+		case WREN_TYPE_BOOL:
+			attrs = append(attrs, slog.Bool(key, vm.GetSlotBool(4)))
+		case WREN_TYPE_NUM:
+			attrs = append(attrs, slog.Float64(key, vm.GetSlotDouble(4)))
+		case WREN_TYPE_STRING:
+			attrs = append(attrs, slog.String(key, vm.GetSlotString(4)))
+		default:
+			attrs = append(attrs, slog.Any(key, nil))
+		}
+	}
+
+	vm.logger.LogAttrs(context.Background(), level, message, attrs...)
+	vm.SetSlotNull(0)
+}
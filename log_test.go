@@ -0,0 +1,68 @@
+package wrengo
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestLogModule(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := NewConfiguration()
+	config.Logger = newTestLogger(&buf)
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "log" for Log
+		Log.info("player joined", {"name": "Damien", "level": 3})
+		Log.error("boom")
+	`))
+
+	out := buf.String()
+	assert.Contains(t, out, `level=INFO msg="player joined"`)
+	assert.Contains(t, out, "name=Damien")
+	assert.Contains(t, out, "level=3")
+	assert.Contains(t, out, "level=ERROR msg=boom")
+}
+
+func TestCallbackErrorSlog(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := NewConfiguration()
+	config.ErrorFunc = CallbackErrorSlog(newTestLogger(&buf))
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.Error(t, vm.Interpret(DefaultModule, `var x = `))
+	assert.Contains(t, buf.String(), "type=ERROR_COMPILE module=main line=1")
+
+	buf.Reset()
+	assert.Error(t, vm.Interpret(DefaultModule, `
+		class Boom {
+			static go() { Fiber.abort("boom") }
+		}
+		Boom.go()
+	`))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "msg=boom type=ERROR_RUNTIME module=main line=3")
+	assert.Contains(t, buf.String(), "stack.0.module=main stack.0.line=3")
+	assert.Contains(t, buf.String(), "go()")
+	assert.Contains(t, buf.String(), "stack.1.module=main")
+}
@@ -0,0 +1,155 @@
+package wrengo
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// A Wren module provided by the host instead of [LoadModuleFunc].
+//
+// Scripts import it like any other module. The foreign methods declared in its
+// source are bound to the Go functions in [Methods].
+type Module struct {
+	// The name scripts use to import the module.
+	Name string
+
+	// Wren source code of the module.
+	Source string
+
+	// Foreign methods declared in [Source], keyed by class and signature,
+	// like "Log.write_(_,_,_)" or "static Log.write_(_,_,_)".
+	Methods map[string]func(*VM)
+
+	// Foreign classes declared in [Source], keyed by name. Each returns the Go
+	// value held by a new instance, which [GetSlotObject] reads back, given
+	// the arguments of the constructor in slots 1 and up.
+	//
+	// The allocator can't abort the fiber, validate arguments in the
+	// constructor instead.
+	Classes map[string]func(*VM) interface{}
+}
+
+var (
+	// Foreign method and allocator pointers of every registered module. A
+	// module is registered only once, no matter how many VMs use it, and
+	// built-in modules only once a script imports them, so they don't eat up
+	// the foreign function limit.
+	modulePtrs      = make(map[*Module]*modulePointers)
+	modulePtrsGuard sync.Mutex
+)
+
+type modulePointers struct {
+	methods, classes map[string]unsafe.Pointer
+}
+
+func (m *Module) pointers() (*modulePointers, error) {
+	modulePtrsGuard.Lock()
+	defer modulePtrsGuard.Unlock()
+
+	if ptrs, ok := modulePtrs[m]; ok {
+		return ptrs, nil
+	}
+
+	ptrs := &modulePointers{
+		methods: make(map[string]unsafe.Pointer, len(m.Methods)),
+		classes: make(map[string]unsafe.Pointer, len(m.Classes)),
+	}
+	for signature, f := range m.Methods {
+		ptr, err := registerFunc(signature, f)
+		if err != nil {
+			return nil, err
+		}
+		ptrs.methods[signature] = ptr
+	}
+	for name, f := range m.Classes {
+		// Allocators are called like foreign methods, so they are given the VM.
+		ptr, err := registerFunc(name, func(vm *VM) {
+			vm.SetSlotNewObject(0, 0, f(vm))
+		})
+		if err != nil {
+			return nil, err
+		}
+		ptrs.classes[name] = ptr
+	}
+	modulePtrs[m] = ptrs
+	return ptrs, nil
+}
+
+// Makes [module] available to scripts running in the virtual machine.
+//
+// The same [Module] can be registered with any number of VMs.
+func (vm *VM) RegisterModule(module *Module) error {
+	if module.Name == DefaultModule {
+		return fmt.Errorf("module %q is reserved", module.Name)
+	}
+
+	if _, err := module.pointers(); err != nil {
+		return err
+	}
+	vm.modules[module.Name] = module
+	return nil
+}
+
+// Offers the built-in [module] to scripts. It is registered once a script
+// imports it, unless [LoadModuleFunc] provides a module of the same name.
+func (vm *VM) offer(module *Module) {
+	vm.builtins[module.Name] = module
+}
+
+// Reports whether [LoadModuleFunc] provides the module called [name].
+func (vm *VM) provides(name string) bool {
+	return vm.cb.LoadModuleFunc != nil && vm.cb.LoadModuleFunc(vm, name) != ""
+}
+
+// Returns the pointer of a foreign method declared in a registered module.
+func (vm *VM) moduleMethod(module, signature string) unsafe.Pointer {
+	m, ok := vm.modules[module]
+	if !ok {
+		return nil
+	}
+
+	ptrs, err := m.pointers()
+	if err != nil {
+		return nil
+	}
+	return ptrs.methods[signature]
+}
+
+// Returns the pointer of the allocator of a foreign class declared in a
+// registered module.
+func (vm *VM) moduleClass(module, class string) unsafe.Pointer {
+	m, ok := vm.modules[module]
+	if !ok {
+		return nil
+	}
+
+	ptrs, err := m.pointers()
+	if err != nil {
+		return nil
+	}
+	return ptrs.classes[class]
+}
+
+// Makes sure the registered [module] is loaded, so its variables can be read
+// with [GetVariable] before any script imported it.
+//
+// It must not be called from a foreign method.
+func (vm *VM) require(module *Module) error {
+	if m, ok := vm.modules[module.Name]; ok && m != module {
+		return fmt.Errorf("wrengo: module %q is replaced by another module", module.Name)
+	}
+	if vm.loaded[module.Name] {
+		return nil
+	}
+	if _, ok := vm.sources[module.Name]; ok {
+		return fmt.Errorf("wrengo: module %q is replaced by LoadModuleFunc", module.Name)
+	}
+
+	vm.modules[module.Name] = module
+	if err := vm.Interpret(module.Name, module.Source); err != nil {
+		return err
+	}
+	vm.loaded[module.Name] = true
+	return nil
+}
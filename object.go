@@ -0,0 +1,83 @@
+package wrengo
+
+/*
+#include <stdint.h>
+#include "wren.h"
+
+extern void wrengoFinalize(void*);
+*/
+import "C"
+import (
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// Go values held by foreign objects of the classes of a [Module].
+//
+// Go pointers can't be kept in memory owned by Wren, so foreign objects only
+// store an ID into this registry. Finalizers aren't given their VM, which is
+// why the registry is shared by all of them.
+var (
+	objects      = make(map[uint64]interface{})
+	objectsNext  uint64
+	objectsGuard sync.Mutex
+)
+
+// Creates a foreign object of the class in [classSlot] holding [value] and
+// stores it in [slot], which [GetSlotObject] reads back.
+//
+// The class must be a foreign class of a [Module], so the object is finalized.
+func (vm *VM) SetSlotNewObject(slot, classSlot int, value interface{}) {
+	objectsGuard.Lock()
+	objectsNext++
+	id := objectsNext
+	objects[id] = value
+	objectsGuard.Unlock()
+
+	defer vm.enter()()
+	ptr := C.wrenSetSlotNewForeign(vm.vm, C.int(slot), C.int(classSlot), C.size_t(unsafe.Sizeof(C.uint64_t(0))))
+	*(*C.uint64_t)(ptr) = C.uint64_t(id)
+}
+
+// Stores a new instance of the foreign [class] of [module] holding [value] in
+// slot 0, for foreign methods returning objects of another class than the one
+// they are called on.
+func (vm *VM) returnObject(module, class string, value interface{}) {
+	vm.EnsureSlots(3)
+	vm.GetVariable(module, class, 2)
+	vm.SetSlotNewObject(0, 2, value)
+}
+
+// Returns the Go value held by the foreign object in [slot].
+//
+// It is an error to call this if the slot does not contain an instance of a
+// foreign class of a [Module].
+func (vm *VM) GetSlotObject(slot int) interface{} {
+	defer vm.enter()()
+	id := uint64(*(*C.uint64_t)(C.wrenGetSlotForeign(vm.vm, C.int(slot))))
+
+	objectsGuard.Lock()
+	defer objectsGuard.Unlock()
+	return objects[id]
+}
+
+// Values implementing [io.Closer] are closed once their object is garbage
+// collected or the VM is freed. Close must not use the VM.
+//
+//export wrengoFinalize
+func wrengoFinalize(data unsafe.Pointer) {
+	id := uint64(*(*C.uint64_t)(data))
+
+	objectsGuard.Lock()
+	value := objects[id]
+	delete(objects, id)
+	objectsGuard.Unlock()
+
+	if c, ok := value.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// The finalizer of every foreign class of a [Module].
+var finalizeObject = C.WrenFinalizerFn(C.wrengoFinalize)
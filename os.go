@@ -0,0 +1,102 @@
+package wrengo
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// Configures the built-in "os" module. Scripts can only use the module when
+// the configuration has one.
+type OSConfig struct {
+	// What `Process.arguments` returns.
+	Arguments []string
+
+	// Names of the environment variables `Process.env(_)` can read. Other
+	// variables read as null.
+	Env []string
+}
+
+// Returned by [Interpret], [Call] and [RunLoop] when the script called
+// `Process.exit(_)`.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// The built-in "os" module, enabled by the [OS] of the configuration:
+//
+//	import "os" for Process, Platform
+//
+//	System.print(Process.arguments)
+//	System.print(Process.env("HOME"))
+//	System.print(Platform.name)
+//	Process.exit(1)
+//
+// Exiting suspends the VM instead of terminating the host, which sees an
+// [ExitError] instead.
+var osModule = &Module{
+	Name: "os",
+	Source: `
+class Process {
+  foreign static arguments
+
+  static env(name) {
+    if (!(name is String)) Fiber.abort("Name must be a string.")
+    return env_(name)
+  }
+
+  static exit() { exit(0) }
+  static exit(code) {
+    if (!(code is Num) || !code.isInteger) Fiber.abort("Exit code must be an integer.")
+    exit_(code)
+    Fiber.suspend()
+  }
+
+  foreign static env_(name)
+  foreign static exit_(code)
+}
+
+class Platform {
+  foreign static name
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Process.arguments": osProcessArguments,
+		"static Process.env_(_)":   osProcessEnv,
+		"static Process.exit_(_)":  osProcessExit,
+		"static Platform.name":     osPlatformName,
+	},
+}
+
+func osProcessArguments(vm *VM) {
+	vm.SetSlotValue(0, append([]string{}, vm.os.Arguments...))
+}
+
+func osProcessEnv(vm *VM) {
+	name := vm.GetSlotString(1)
+	for _, allowed := range vm.os.Env {
+		if allowed != name {
+			continue
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			vm.SetSlotString(0, value)
+			return
+		}
+		break
+	}
+	vm.SetSlotNull(0)
+}
+
+func osProcessExit(vm *VM) {
+	code := int(vm.GetSlotDouble(1))
+	vm.state.exit = &code
+	vm.SetSlotNull(0)
+}
+
+func osPlatformName(vm *VM) {
+	vm.SetSlotString(0, runtime.GOOS)
+}
@@ -0,0 +1,59 @@
+package wrengo
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOS(t *testing.T) {
+	var out bytes.Buffer
+	t.Setenv("WRENGO_VISIBLE", "visible")
+	t.Setenv("WRENGO_HIDDEN", "hidden")
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.OS = &OSConfig{
+		Arguments: []string{"a", "b"},
+		Env:       []string{"WRENGO_VISIBLE", "WRENGO_MISSING"},
+	}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "os" for Process, Platform
+		System.print(Process.arguments)
+		System.print(Process.env("WRENGO_VISIBLE"))
+		System.print(Process.env("WRENGO_HIDDEN"))
+		System.print(Process.env("WRENGO_MISSING"))
+		System.print(Platform.name)
+	`))
+	assert.Equal(t, "[a, b]\nvisible\nnull\nnull\n"+runtime.GOOS+"\n", out.String())
+	assert.Error(t, vm.Interpret(DefaultModule, `Process.env(1)`))
+}
+
+func TestOSExit(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.OS = &OSConfig{}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	err := vm.Interpret(DefaultModule, `
+		import "os" for Process
+		Fiber.new {
+			System.print("exiting")
+			Process.exit(3)
+		}.try()
+		System.print("not reached")
+	`)
+	assert.Equal(t, &ExitError{Code: 3}, err)
+	assert.Equal(t, "exiting\n", out.String())
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `System.print("still usable")`))
+	assert.Equal(t, "exiting\nstill usable\n", out.String())
+}
@@ -0,0 +1,222 @@
+package wrengo
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Returned by [Pool.Get] once the pool is closed.
+var ErrPoolClosed = errors.New("wrengo: pool closed")
+
+// Describes how a [Pool] builds its virtual machines.
+type PoolConfig struct {
+	// The configuration every VM is created with.
+	Configuration Configuration
+
+	// Binds foreign classes and methods, and registers modules, on a new VM.
+	//
+	// Foreign functions bound with [BindForeignMethod] count against the
+	// registration limit once per VM, while a [Module] registered with
+	// [RegisterModule] is counted only once, so prefer modules for pooled VMs.
+	Bind func(vm *VM) error
+
+	// Source interpreted in the main module of every new VM, before it is
+	// handed out. Use it to warm up the VM with the classes and imports scripts
+	// rely on.
+	Source string
+
+	// The maximum number of VMs alive at once, idle or in use.
+	//
+	// If zero, defaults to the number of CPUs.
+	MaxSize int
+
+	// VMs still holding more than this many bytes after a garbage collection
+	// are discarded when put back.
+	//
+	// If zero, VMs are never discarded because of their memory.
+	MaxBytes int
+}
+
+// Statistics of a [Pool].
+type PoolStats struct {
+	// VMs waiting in the pool.
+	Idle int
+
+	// VMs handed out by [Get] and not put back yet.
+	InUse int
+
+	// VMs created since the pool was made.
+	Created uint64
+
+	// VMs freed instead of going back to the pool while it is open, because
+	// they hit a runtime error, held too much memory or were passed to
+	// [Discard].
+	Discarded uint64
+
+	// Calls to [Get] that had to wait for a VM to be put back.
+	Waited uint64
+}
+
+// A pool of virtual machines built from the same configuration, so scripts can
+// run concurrently without creating a VM each time.
+//
+// A VM taken with [Get] must only be used by one goroutine until it is
+// returned with [Put].
+type Pool struct {
+	config PoolConfig
+
+	// Holds a token for every VM in use, limiting the size of the pool.
+	tokens  chan struct{}
+	factory sync.Mutex
+
+	mu     sync.Mutex
+	idle   []*VM
+	stats  PoolStats
+	closed bool
+}
+
+// Creates a pool building VMs as described by [config]. VMs are created on
+// demand by [Get].
+func NewPool(config PoolConfig) *Pool {
+	if config.MaxSize <= 0 {
+		config.MaxSize = runtime.NumCPU()
+	}
+
+	return &Pool{
+		config: config,
+		tokens: make(chan struct{}, config.MaxSize),
+	}
+}
+
+// Takes a VM from the pool, creating one if none is idle. If the pool is at
+// its maximum size, waits until a VM is put back or [ctx] is done.
+func (p *Pool) Get(ctx context.Context) (*VM, error) {
+	select {
+	case p.tokens <- struct{}{}:
+	default:
+		p.mu.Lock()
+		p.stats.Waited++
+		p.mu.Unlock()
+
+		select {
+		case p.tokens <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.tokens
+		return nil, ErrPoolClosed
+	}
+
+	if n := len(p.idle); n > 0 {
+		vm := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.stats.Idle--
+		p.stats.InUse++
+		p.mu.Unlock()
+		return vm, nil
+	}
+
+	p.mu.Unlock()
+
+	vm, err := p.newVM()
+	if err != nil {
+		<-p.tokens
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.stats.Created++
+	p.stats.InUse++
+	p.mu.Unlock()
+	return vm, nil
+}
+
+func (p *Pool) newVM() (*VM, error) {
+	// The configuration is shared by every VM, so they are created one at a
+	// time.
+	p.factory.Lock()
+	vm := NewVM(p.config.Configuration)
+	p.factory.Unlock()
+
+	if p.config.Bind != nil {
+		if err := p.config.Bind(&vm); err != nil {
+			vm.FreeVM()
+			return nil, err
+		}
+	}
+
+	if p.config.Source != "" {
+		if err := vm.Interpret(DefaultModule, p.config.Source); err != nil {
+			vm.FreeVM()
+			return nil, err
+		}
+	}
+
+	return &vm, nil
+}
+
+// Returns [vm], previously taken with [Get], to the pool.
+//
+// The VM is freed instead if a script running in it hit a runtime error, if it
+// holds more memory than allowed, or if the pool is closed.
+func (p *Pool) Put(vm *VM) {
+	discard := vm.state.failed
+	if !discard && p.config.MaxBytes > 0 && vm.BytesAllocated() > p.config.MaxBytes {
+		vm.GC()
+		discard = vm.BytesAllocated() > p.config.MaxBytes
+	}
+	p.put(vm, discard)
+}
+
+// Frees [vm], previously taken with [Get], instead of returning it to the
+// pool. Use it for VMs left in a state the next user shouldn't see, like
+// fibers still waiting for asynchronous work.
+func (p *Pool) Discard(vm *VM) {
+	p.put(vm, true)
+}
+
+func (p *Pool) put(vm *VM, discard bool) {
+	p.mu.Lock()
+	p.stats.InUse--
+	switch {
+	case p.closed:
+		vm.FreeVM()
+	case discard:
+		vm.FreeVM()
+		p.stats.Discarded++
+	default:
+		p.idle = append(p.idle, vm)
+		p.stats.Idle++
+	}
+	p.mu.Unlock()
+
+	<-p.tokens
+}
+
+// Returns the current statistics of the pool.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// Frees every idle VM. VMs in use are freed when they are put back, and [Get]
+// fails from now on.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for _, vm := range p.idle {
+		vm.FreeVM()
+	}
+	p.idle = nil
+	p.stats.Idle = 0
+}
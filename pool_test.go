@@ -0,0 +1,78 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	config := NewConfiguration()
+	pool := NewPool(PoolConfig{
+		Configuration: config,
+		Source:        `class Greeter { static greet(name) { System.print("Hello, %(name)!") } }`,
+		MaxSize:       2,
+	})
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			vm, err := pool.Get(context.Background())
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer pool.Put(vm)
+
+			var out bytes.Buffer
+			assert.NoError(t, vm.InterpretTo(&out, DefaultModule, `Greeter.greet("pool")`))
+			assert.Equal(t, "Hello, pool!\n", out.String())
+		}()
+	}
+	wg.Wait()
+
+	stats := pool.Stats()
+	assert.LessOrEqual(t, stats.Created, uint64(2))
+	assert.Equal(t, 0, stats.InUse)
+	assert.Equal(t, int(stats.Created), stats.Idle)
+}
+
+func TestPoolDiscardsFailedVMs(t *testing.T) {
+	pool := NewPool(PoolConfig{Configuration: NewConfiguration(), MaxSize: 1})
+	defer pool.Close()
+
+	vm, err := pool.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Error(t, vm.Interpret(DefaultModule, `Fiber.abort("boom")`))
+	pool.Put(vm)
+
+	assert.Equal(t, PoolStats{Created: 1, Discarded: 1}, pool.Stats())
+
+	vm, err = pool.Get(context.Background())
+	assert.NoError(t, err)
+	pool.Discard(vm)
+
+	assert.Equal(t, PoolStats{Created: 2, Discarded: 2}, pool.Stats())
+}
+
+func TestPoolGetTimeout(t *testing.T) {
+	pool := NewPool(PoolConfig{Configuration: NewConfiguration(), MaxSize: 1})
+	defer pool.Close()
+
+	vm, err := pool.Get(context.Background())
+	assert.NoError(t, err)
+	defer pool.Put(vm)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Get(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, uint64(1), pool.Stats().Waited)
+}
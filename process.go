@@ -0,0 +1,393 @@
+package wrengo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Configures the built-in "process" module. Scripts can only use the module
+// when the configuration has one.
+type ProcessConfig struct {
+	// The commands scripts may run, as they name them. Names without a path
+	// separator are looked up in the PATH.
+	Commands []string
+
+	// The directory commands run in. Working directories given by scripts are
+	// relative to it, and can't leave it.
+	//
+	// If this is empty, the working directory of the host is used.
+	Dir string
+}
+
+// The built-in "process" module, enabled by the [Process] of the
+// configuration:
+//
+//	import "process" for Process
+//
+//	var result = Process.run("go", ["build", "./..."], {"dir": "src"})
+//	if (!result.ok) Fiber.abort(result.stderr)
+//
+//	var child = Process.start("go", ["test", "-v", "./..."])
+//	var line
+//	while (line = child.readLine()) System.print(line)
+//	System.print(child.wait())
+//
+// Commands run asynchronously, see [VM.RunLoop]. Commands that aren't allowed
+// or can't be started abort the fiber, while failing ones just exit with
+// their code.
+//
+// The Process class is not the one of the "os" module, so a module can only
+// import one of them.
+var processModule = &Module{
+	Name: "process",
+	Source: `
+import "scheduler" for Scheduler
+
+class Process {
+  static run(command) { run(command, [], {}) }
+  static run(command, args) { run(command, args, {}) }
+  static run(command, args, options) {
+    validate_(command, args, options)
+    run_(command, args, options["dir"], options["stdin"], Fiber.current)
+    var result = Scheduler.runNextScheduled_()
+    return ProcessResult.new_(result[0], result[1], result[2])
+  }
+
+  static start(command) { start(command, [], {}) }
+  static start(command, args) { start(command, args, {}) }
+  static start(command, args, options) {
+    validate_(command, args, options)
+    return start_(command, args, options["dir"])
+  }
+
+  static validate_(command, args, options) {
+    if (!(command is String)) Fiber.abort("Command must be a string.")
+    if (!(args is List) || args.any {|arg| !(arg is String) }) {
+      Fiber.abort("Arguments must be a list of strings.")
+    }
+    if (!(options is Map)) Fiber.abort("Options must be a map.")
+    for (key in ["dir", "stdin"]) {
+      if (options[key] != null && !(options[key] is String)) {
+        Fiber.abort("Option '%(key)' must be a string.")
+      }
+    }
+  }
+
+  foreign static run_(command, args, dir, stdin, fiber)
+  foreign static start_(command, args, dir)
+}
+
+class ProcessResult {
+  construct new_(code, stdout, stderr) {
+    _code = code
+    _stdout = stdout
+    _stderr = stderr
+  }
+
+  code { _code }
+  ok { _code == 0 }
+  stdout { _stdout }
+  stderr { _stderr }
+
+  toString { "ProcessResult(%(_code))" }
+}
+
+foreign class ChildProcess {
+  // Returns the next line of the output of the process, or null once it is
+  // closed.
+  readLine() {
+    readLine_(Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  write(text) {
+    if (!(text is String)) Fiber.abort("Text must be a string.")
+    write_(text, Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  // Waits for the process to exit and returns its code.
+  wait() {
+    wait_(Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  foreign closeStdin()
+  foreign kill()
+
+  // What the process wrote to its error output, once it exited.
+  foreign stderr
+
+  foreign readLine_(fiber)
+  foreign write_(text, fiber)
+  foreign wait_(fiber)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Process.run_(_,_,_,_,_)": processRun,
+		"static Process.start_(_,_,_)":   processStart,
+		"ChildProcess.closeStdin()":      childCloseStdin,
+		"ChildProcess.kill()":            childKill,
+		"ChildProcess.stderr":            childStderr,
+		"ChildProcess.readLine_(_)":      childReadLine,
+		"ChildProcess.write_(_,_)":       childWrite,
+		"ChildProcess.wait_(_)":          childWait,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		// Instances are only created by Process.start.
+		"ChildProcess": func(*VM) interface{} { return nil },
+	},
+}
+
+// Prepares the command of a script for [config], checking it is allowed.
+func (config *ProcessConfig) command(name string, args []string, dir string) (*exec.Cmd, error) {
+	if !slices.Contains(config.Commands, name) {
+		return nil, fmt.Errorf("Command '%s' is not allowed.", name)
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	root := config.Dir
+	if root == "" {
+		root = "."
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Symbolic links are resolved, so they can't lead out of the root either.
+	wd, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(fsPath(dir))))
+	if err != nil {
+		return nil, err
+	}
+	if rel, err := filepath.Rel(root, wd); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("Directory '%s' is outside of the working directory.", dir)
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Dir = wd
+	return cmd, nil
+}
+
+// Reads the command, arguments and working directory of Process.run_ and
+// Process.start_ and prepares the command.
+func processCommand(vm *VM) (*exec.Cmd, error) {
+	value, err := vm.GetSlotValue(2)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	for _, arg := range value.([]interface{}) {
+		args = append(args, arg.(string))
+	}
+
+	var dir string
+	if vm.GetSlotType(3) == WREN_TYPE_STRING {
+		dir = vm.GetSlotString(3)
+	}
+	return vm.process.command(vm.GetSlotString(1), args, dir)
+}
+
+func processRun(vm *VM) {
+	cmd, err := processCommand(vm)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if vm.GetSlotType(4) == WREN_TYPE_STRING {
+		cmd.Stdin = bytes.NewReader(vm.GetSlotBytes(4, 0))
+	}
+
+	vm.Async(5, func(ctx context.Context) (interface{}, error) {
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				cmd.Process.Kill()
+			case <-done:
+			}
+		}()
+
+		err := cmd.Wait()
+		var exit *exec.ExitError
+		if err != nil && !errors.As(err, &exit) {
+			return nil, err
+		}
+		return []interface{}{cmd.ProcessState.ExitCode(), stdout.Bytes(), stderr.Bytes()}, nil
+	})
+	vm.SetSlotNull(0)
+}
+
+// The Go value of a ChildProcess.
+type childProcess struct {
+	cmd *exec.Cmd
+
+	// Pipes to the process. Its output is read by one fiber at a time.
+	stdin   *os.File
+	stdout  *bufio.Reader
+	reading sync.Mutex
+	output  *os.File
+
+	// Closed once the process exited, and [stderr] is complete.
+	done   chan struct{}
+	stderr bytes.Buffer
+}
+
+func processStart(vm *VM) {
+	cmd, err := processCommand(vm)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+
+	c, err := startChild(cmd)
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.returnObject("process", "ChildProcess", c)
+}
+
+func startChild(cmd *exec.Cmd) (*childProcess, error) {
+	c := &childProcess{cmd: cmd, done: make(chan struct{})}
+
+	// The pipes are made by hand, since [exec.Cmd.Wait] closes its own ones
+	// while they may still be read.
+	stdin, childStdin, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	childStdout, stdout, err := os.Pipe()
+	if err != nil {
+		stdin.Close()
+		childStdin.Close()
+		return nil, err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, &c.stderr
+
+	err = cmd.Start()
+	stdin.Close()
+	stdout.Close()
+	if err != nil {
+		childStdin.Close()
+		childStdout.Close()
+		return nil, err
+	}
+
+	c.stdin = childStdin
+	c.output = childStdout
+	c.stdout = bufio.NewReader(childStdout)
+	go func() {
+		cmd.Wait()
+		close(c.done)
+	}()
+	return c, nil
+}
+
+// Kills the process if it still runs and closes the pipes, once the
+// ChildProcess is garbage collected.
+func (c *childProcess) Close() error {
+	select {
+	case <-c.done:
+	default:
+		c.cmd.Process.Kill()
+	}
+	c.stdin.Close()
+	return c.output.Close()
+}
+
+func childOf(vm *VM) *childProcess {
+	return vm.GetSlotObject(0).(*childProcess)
+}
+
+func childCloseStdin(vm *VM) {
+	childOf(vm).stdin.Close()
+	vm.SetSlotNull(0)
+}
+
+func childKill(vm *VM) {
+	c := childOf(vm)
+	select {
+	case <-c.done:
+	default:
+		c.cmd.Process.Kill()
+	}
+	vm.SetSlotNull(0)
+}
+
+func childStderr(vm *VM) {
+	c := childOf(vm)
+	select {
+	case <-c.done:
+		vm.SetSlotBytes(0, c.stderr.Bytes())
+	default:
+		vm.SetSlotNull(0)
+	}
+}
+
+func childReadLine(vm *VM) {
+	c := childOf(vm)
+	vm.Async(1, func(ctx context.Context) (interface{}, error) {
+		c.reading.Lock()
+		defer c.reading.Unlock()
+
+		line, err := c.stdout.ReadString('\n')
+		switch {
+		case err == io.EOF && line == "":
+			return nil, nil
+		case err != nil && err != io.EOF:
+			return nil, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		return strings.TrimSuffix(line, "\r"), nil
+	})
+	vm.SetSlotNull(0)
+}
+
+func childWrite(vm *VM) {
+	var (
+		c    = childOf(vm)
+		text = vm.GetSlotBytes(1, 0)
+	)
+	vm.Async(2, func(ctx context.Context) (interface{}, error) {
+		_, err := c.stdin.Write(text)
+		return nil, err
+	})
+	vm.SetSlotNull(0)
+}
+
+func childWait(vm *VM) {
+	c := childOf(vm)
+	vm.Async(1, func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-c.done:
+			return c.cmd.ProcessState.ExitCode(), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
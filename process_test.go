@@ -0,0 +1,62 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessRun(t *testing.T) {
+	var out bytes.Buffer
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.Process = &ProcessConfig{Commands: []string{"sh", "cat"}, Dir: dir}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "process" for Process
+		var result = Process.run("sh", ["-c", "echo out; echo err >&2; exit 3"])
+		System.print([result.code, result.ok, result.stdout, result.stderr])
+
+		result = Process.run("sh", ["-c", "basename $(pwd)"], {"dir": "/sub"})
+		System.print(result.stdout.trim())
+
+		System.print(Process.run("cat", [], {"stdin": "piped"}).stdout)
+	`))
+	assert.NoError(t, vm.RunLoop(context.Background()))
+	assert.Equal(t, "[3, false, out\n, err\n]\nsub\npiped\n", out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Process.run("rm", ["-rf", "/"])`))
+	assert.NoError(t, os.Symlink(os.TempDir(), filepath.Join(dir, "escape")))
+	assert.Error(t, vm.Interpret(DefaultModule, `Process.run("cat", [], {"dir": "escape"})`))
+}
+
+func TestProcessStart(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.Process = &ProcessConfig{Commands: []string{"cat"}}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "process" for Process
+		var child = Process.start("cat")
+		child.write("first\nsecond\n")
+		child.closeStdin()
+		var line
+		while (line = child.readLine()) System.print(line)
+		System.print([child.wait(), child.stderr])
+	`))
+	assert.NoError(t, vm.RunLoop(context.Background()))
+	assert.Equal(t, "first\nsecond\n[0, ]\n", out.String())
+}
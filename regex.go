@@ -0,0 +1,181 @@
+package wrengo
+
+import (
+	"regexp"
+)
+
+// The built-in "regex" module, with the syntax of Go's [regexp] package:
+//
+//	import "regex" for Regex
+//
+//	var date = Regex.new("(?P<year>\\d{4})-(?P<month>\\d{2})")
+//	var match = date.match("Released 2017-12")
+//	System.print(match.text)
+//	System.print(match["year"])
+//	System.print(date.findAll("2017-12 and 2018-01").map {|m| m[1] }.toList)
+//	System.print(date.replaceAll("2017-12", "${month}/${year}"))
+//
+// Positions are byte offsets, like the ones of strings. Invalid patterns abort
+// the fiber creating the regex.
+var regexModule = &Module{
+	Name: "regex",
+	Source: `
+import "wrengo/value" for Value
+
+foreign class Regex {
+  construct new(pattern) {
+    if (!(pattern is String)) Fiber.abort("Pattern must be a string.")
+    compile_(pattern)
+  }
+
+  foreign pattern
+
+  test(string) {
+    validate_(string)
+    return test_(string)
+  }
+
+  match(string) {
+    validate_(string)
+    var match = match_(string)
+    return match == null ? null : Match.new_(Value.decode(match))
+  }
+
+  findAll(string) {
+    validate_(string)
+    return Value.decode(findAll_(string)).map {|match| Match.new_(match) }.toList
+  }
+
+  replaceAll(string, replacement) {
+    validate_(string)
+    validate_(replacement)
+    return replaceAll_(string, replacement)
+  }
+
+  toString { pattern }
+
+  validate_(string) {
+    if (!(string is String)) Fiber.abort("Argument must be a string.")
+  }
+
+  foreign compile_(pattern)
+  foreign test_(string)
+  foreign match_(string)
+  foreign findAll_(string)
+  foreign replaceAll_(string, replacement)
+}
+
+class Match {
+  construct new_(match) {
+    _text = match[0]
+    _start = match[1]
+    _groups = match[2]
+    _named = match[3]
+  }
+
+  // The matched text and where it starts and ends.
+  text { _text }
+  start { _start }
+  end { _start + _text.bytes.count }
+
+  // Captured groups, null where a group didn't participate in the match.
+  groups { _groups }
+  named { _named }
+
+  [group] {
+    if (group is String) return _named[group]
+    if (group == 0) return _text
+    return _groups[group - 1]
+  }
+
+  toString { _text }
+}
+`,
+	Methods: map[string]func(*VM){
+		"Regex.pattern":          regexPattern,
+		"Regex.compile_(_)":      regexCompile,
+		"Regex.test_(_)":         regexTest,
+		"Regex.match_(_)":        regexMatch,
+		"Regex.findAll_(_)":      regexFindAll,
+		"Regex.replaceAll_(_,_)": regexReplaceAll,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		"Regex": func(*VM) interface{} { return &regex{} },
+	},
+}
+
+// The Go value of a Regex, compiled by its constructor.
+type regex struct {
+	re *regexp.Regexp
+}
+
+func regexOf(vm *VM) *regexp.Regexp {
+	return vm.GetSlotObject(0).(*regex).re
+}
+
+func regexCompile(vm *VM) {
+	re, err := regexp.Compile(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.GetSlotObject(0).(*regex).re = re
+	vm.SetSlotNull(0)
+}
+
+func regexPattern(vm *VM) {
+	vm.SetSlotString(0, regexOf(vm).String())
+}
+
+func regexTest(vm *VM) {
+	vm.SetSlotBool(0, regexOf(vm).MatchString(vm.GetSlotString(1)))
+}
+
+func regexMatch(vm *VM) {
+	var (
+		re = regexOf(vm)
+		s  = vm.GetSlotString(1)
+	)
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		vm.SetSlotNull(0)
+		return
+	}
+	vm.setSlotEncoded(0, regexMatchValue(re, s, loc))
+}
+
+func regexFindAll(vm *VM) {
+	var (
+		re      = regexOf(vm)
+		s       = vm.GetSlotString(1)
+		matches = []interface{}{}
+	)
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		matches = append(matches, regexMatchValue(re, s, loc))
+	}
+	vm.setSlotEncoded(0, matches)
+}
+
+func regexReplaceAll(vm *VM) {
+	vm.SetSlotString(0, regexOf(vm).ReplaceAllString(vm.GetSlotString(1), vm.GetSlotString(2)))
+}
+
+// Returns what the Wren Match class is created from: the text, its start,
+// the groups and the named groups.
+func regexMatchValue(re *regexp.Regexp, s string, loc []int) []interface{} {
+	var (
+		groups = make([]interface{}, 0, len(loc)/2-1)
+		named  = make(map[string]interface{})
+	)
+	for i := 1; i < len(loc)/2; i++ {
+		var group interface{}
+		if loc[2*i] >= 0 {
+			group = s[loc[2*i]:loc[2*i+1]]
+		}
+		groups = append(groups, group)
+		if name := re.SubexpNames()[i]; name != "" {
+			named[name] = group
+		}
+	}
+	return []interface{}{s[loc[0]:loc[1]], loc[0], groups, named}
+}
@@ -0,0 +1,56 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegex(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "regex" for Regex
+		var date = Regex.new("(?P<year>\\d{4})-(?P<month>\\d{2})(-(\\d{2}))?")
+		System.print(date.test("no dates"))
+
+		var match = date.match("Released 2017-12")
+		System.print([match.text, match.start, match.end])
+		System.print([match[0], match[1], match["month"], match[4]])
+		System.print(date.match("nothing"))
+
+		System.print(date.findAll("2017-12 and 2018-01-02").map {|m| m.text }.toList)
+		System.print(date.replaceAll("2017-12", "${month}/${year}"))
+	`))
+	assert.Equal(t, `false
+[2017-12, 9, 16]
+[2017-12, 2017, 12, null]
+null
+[2017-12, 2018-01-02]
+12/2017
+`, out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Regex.new("(")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Regex.new(1)`))
+}
+
+func TestRegexFinalize(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "regex" for Regex
+		for (i in 1..100) Regex.new("a+")
+		System.gc()
+	`))
+	vm.FreeVM()
+
+	objectsGuard.Lock()
+	defer objectsGuard.Unlock()
+	assert.Empty(t, objects)
+}
@@ -0,0 +1,170 @@
+package wrengo
+
+/*
+#include "wren.h"
+*/
+import "C"
+import (
+	"context"
+)
+
+// The built-in "scheduler" module, the event loop of asynchronous foreign
+// methods, modelled after the one of wren-cli.
+//
+// An asynchronous foreign method takes the calling fiber as its last argument
+// and starts its work with [VM.Async]. The Wren method wrapping it then gives
+// control to the next scheduled fiber, or suspends the VM when there is none:
+//
+//	import "scheduler" for Scheduler
+//
+//	class Http {
+//	  static get(url) {
+//	    get_(url, Fiber.current)
+//	    return Scheduler.runNextScheduled_()
+//	  }
+//	  foreign static get_(url, fiber)
+//	}
+//
+// Once the work is done, [VM.RunLoop] resumes the fiber with its result, which
+// is what `runNextScheduled_` returns, or aborts it with the error.
+var schedulerModule = &Module{
+	Name: "scheduler",
+	Source: `
+class Scheduler {
+  static add(callable) {
+    if (__scheduled == null) __scheduled = []
+
+    __scheduled.add(Fiber.new {
+      callable.call()
+      runNextScheduled_()
+    })
+  }
+
+  static resume_(fiber) { fiber.transfer() }
+  static resume_(fiber, arg) { fiber.transfer(arg) }
+  static resumeError_(fiber, error) { fiber.transferError(error) }
+
+  static runNextScheduled_() {
+    if (__scheduled == null || __scheduled.isEmpty) {
+      return Fiber.suspend()
+    } else {
+      return __scheduled.removeAt(0).transfer()
+    }
+  }
+}
+`,
+}
+
+// The event loop of a VM, see [VM.RunLoop].
+type loop struct {
+	// Cancelled when the VM is freed, to stop work still in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Receives the results of asynchronous work.
+	done chan completion
+
+	// Fibers suspended until their work is done.
+	waiting map[*C.WrenHandle]Handle
+}
+
+// The result of asynchronous work started by [VM.Async].
+type completion struct {
+	fiber Handle
+	value interface{}
+	err   error
+}
+
+// A result of asynchronous work to be passed to the fiber as encoded by
+// `Value.encode(_)` of the "wrengo/value" module, so it may contain maps.
+type encodedValue struct {
+	value interface{}
+}
+
+func newLoop() *loop {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &loop{
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan completion),
+		waiting: make(map[*C.WrenHandle]Handle),
+	}
+}
+
+// Stops the work in progress and releases every handle of the loop.
+func (l *loop) free() {
+	l.cancel()
+	for _, fiber := range l.waiting {
+		fiber.Release()
+	}
+	l.waiting = nil
+}
+
+// Starts [work] on its own goroutine for the foreign method being called, so
+// the fiber in [fiberSlot] can suspend until it is done instead of blocking the
+// VM.
+//
+// [work] must not touch the VM. Its context is cancelled when the VM is freed.
+// Once it returns, [RunLoop] resumes the fiber with the value, converted as by
+// [SetSlotValue], or aborts the fiber with the error.
+func (vm *VM) Async(fiberSlot int, work func(ctx context.Context) (interface{}, error)) {
+	l := vm.loop
+	fiber := vm.GetSlotHandle(fiberSlot)
+	l.waiting[fiber.handle] = fiber
+
+	go func() {
+		value, err := work(l.ctx)
+		select {
+		case l.done <- completion{fiber: fiber, value: value, err: err}:
+		case <-l.ctx.Done():
+		}
+	}()
+}
+
+// Resumes fibers suspended by asynchronous foreign methods as their work is
+// done, until no fiber is waiting or [ctx] is done.
+//
+// Returns the error of the first resumed fiber that hits a runtime error.
+func (vm *VM) RunLoop(ctx context.Context) error {
+	l := vm.loop
+	for len(l.waiting) > 0 {
+		select {
+		case c := <-l.done:
+			if err := vm.complete(c); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Resumes the fiber of a completed work with its result.
+func (vm *VM) complete(c completion) error {
+	l := vm.loop
+	delete(l.waiting, c.fiber.handle)
+	defer c.fiber.Release()
+
+	var (
+		resume      = vm.callHandle("resume_(_,_)")
+		resumeError = vm.callHandle("resumeError_(_,_)")
+	)
+
+	vm.EnsureSlots(3)
+	vm.GetVariable(schedulerModule.Name, "Scheduler", 0)
+	vm.SetSlotHandle(1, c.fiber)
+
+	if c.err == nil {
+		if e, ok := c.value.(encodedValue); ok {
+			c.err = vm.setSlotEncoded(2, e.value)
+		} else {
+			c.err = vm.SetSlotValue(2, c.value)
+		}
+	}
+	if c.err != nil {
+		vm.SetSlotString(2, c.err.Error())
+		return resumeError.Call()
+	}
+	return resume.Call()
+}
@@ -0,0 +1,72 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const slowSource = `
+import "scheduler" for Scheduler
+
+class Slow {
+	static double(n) {
+		double_(n, Fiber.current)
+		return Scheduler.runNextScheduled_()
+	}
+	foreign static double_(n, fiber)
+}
+`
+
+func bindSlow(t *testing.T, vm *VM, delay time.Duration) {
+	assert.NoError(t, vm.BindForeignMethod("Slow", true, "double_(_,_)", func(vm *VM) {
+		n := vm.GetSlotDouble(1)
+		vm.Async(2, func(ctx context.Context) (interface{}, error) {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if n < 0 {
+				return nil, errors.New("negative")
+			}
+			return n * 2, nil
+		})
+	}))
+}
+
+func TestAsync(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+	bindSlow(t, &vm, time.Millisecond)
+
+	assert.NoError(t, vm.Interpret(DefaultModule, slowSource+`
+		Scheduler.add { System.print("other fiber") }
+		System.print(Slow.double(21))
+		System.print(Fiber.new { Slow.double(-1) }.try())
+	`))
+	assert.Equal(t, "other fiber\n", out.String())
+
+	assert.NoError(t, vm.RunLoop(context.Background()))
+	assert.Equal(t, "other fiber\n42\nnegative\n", out.String())
+}
+
+func TestRunLoopContext(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+	bindSlow(t, &vm, time.Hour)
+
+	assert.NoError(t, vm.Interpret(DefaultModule, slowSource+`Slow.double(1)`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, vm.RunLoop(ctx))
+}
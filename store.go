@@ -0,0 +1,386 @@
+package wrengo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Where the built-in "store" module keeps the values of scripts between runs.
+//
+// Values are the ones [json.Unmarshal] produces: nil, bool, float64, string,
+// []interface{} and map[string]interface{}.
+type Storage interface {
+	// Opens the store called [name], creating it if needed.
+	Open(name string) (Store, error)
+}
+
+// A set of values keyed by string, opened from a [Storage].
+//
+// A store may be used by several VMs at once.
+type Store interface {
+	// Returns the value of [key], and whether there is one.
+	Get(key string) (interface{}, bool, error)
+
+	Set(key string, value interface{}) error
+	Delete(key string) error
+
+	// Returns every key, sorted.
+	Keys() ([]string, error)
+}
+
+// The built-in "store" module, enabled by the [Storage] of the configuration:
+//
+//	import "store" for Store
+//
+//	var save = Store.open("save")
+//	var level = save.get("level") || 1
+//	save.set("level", level + 1)
+//	save.set("inventory", {"sword": 1, "potions": [10, 20]})
+//	System.print(save.keys)
+//
+// Values must be null, booleans, numbers, strings, lists and maps with string
+// keys, else setting them aborts the fiber.
+var storeModule = &Module{
+	Name: "store",
+	Source: `
+import "wrengo/value" for Value
+
+foreign class Store {
+  static open(name) {
+    if (!(name is String)) Fiber.abort("Name must be a string.")
+    return open_(name)
+  }
+
+  get(key) { Value.decode(get_(validate_(key))) }
+
+  set(key, value) {
+    set_(validate_(key), Value.encode(value))
+    return value
+  }
+
+  delete(key) { delete_(validate_(key)) }
+
+  foreign keys
+
+  validate_(key) {
+    if (!(key is String)) Fiber.abort("Key must be a string.")
+    return key
+  }
+
+  foreign static open_(name)
+  foreign get_(key)
+  foreign set_(key, value)
+  foreign delete_(key)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Store.open_(_)": storeOpen,
+		"Store.get_(_)":         storeGet,
+		"Store.set_(_,_)":       storeSet,
+		"Store.delete_(_)":      storeDelete,
+		"Store.keys":            storeKeys,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		// Instances are only created by Store.open.
+		"Store": func(*VM) interface{} { return nil },
+	},
+}
+
+func storeOpen(vm *VM) {
+	store, err := vm.storage.Open(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNewObject(0, 0, store)
+}
+
+func storeOf(vm *VM) Store {
+	return vm.GetSlotObject(0).(Store)
+}
+
+func storeGet(vm *VM) {
+	value, ok, err := storeOf(vm).Get(vm.GetSlotString(1))
+	switch {
+	case err != nil:
+		vm.abortFiber(err.Error())
+	case !ok:
+		vm.SetSlotNull(0)
+	default:
+		if err := vm.setSlotEncoded(0, value); err != nil {
+			vm.abortFiber(err.Error())
+		}
+	}
+}
+
+func storeSet(vm *VM) {
+	value, err := vm.getSlotEncoded(2)
+	if err == nil {
+		value, err = storeValue(value)
+	}
+	if err == nil {
+		err = storeOf(vm).Set(vm.GetSlotString(1), value)
+	}
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNull(0)
+}
+
+func storeDelete(vm *VM) {
+	if err := storeOf(vm).Delete(vm.GetSlotString(1)); err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNull(0)
+}
+
+func storeKeys(vm *VM) {
+	keys, err := storeOf(vm).Keys()
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotValue(0, keys)
+}
+
+// Converts a decoded Wren value into one a [Store] holds, refusing what JSON
+// can't represent.
+func storeValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, element := range v {
+			element, err := storeValue(element)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = element
+		}
+		return v, nil
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("Map keys must be strings, not %v.", key)
+			}
+			element, err := storeValue(element)
+			if err != nil {
+				return nil, err
+			}
+			object[k] = element
+		}
+		return object, nil
+	default:
+		// Numbers JSON has no representation for are refused here.
+		if _, err := json.Marshal(v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// Copies a value for a store through JSON, so it doesn't share anything with
+// the caller.
+func storeCopy(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
+
+// A [Storage] keeping its stores in memory, lost once the host exits.
+type MemoryStorage struct {
+	mu     sync.Mutex
+	stores map[string]*memoryStore
+}
+
+// Creates an empty [MemoryStorage].
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{stores: make(map[string]*memoryStore)}
+}
+
+func (s *MemoryStorage) Open(name string) (Store, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, ok := s.stores[name]
+	if !ok {
+		store = &memoryStore{values: make(map[string]interface{})}
+		s.stores[name] = store
+	}
+	return store, nil
+}
+
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+func (s *memoryStore) Get(key string) (interface{}, bool, error) {
+	s.mu.Lock()
+	value, ok := s.values[key]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+	value, err := storeCopy(value)
+	return value, err == nil, err
+}
+
+func (s *memoryStore) Set(key string, value interface{}) error {
+	value, err := storeCopy(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+func (s *memoryStore) Keys() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.values), nil
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// A [Storage] keeping every store in a JSON file of a directory, named after
+// the store: "save" is kept in "save.json".
+//
+// Files are rewritten as a whole on every change, so stores should stay small.
+type FileStorage struct {
+	dir string
+
+	// Guards every file, since stores of the same name share one.
+	mu sync.Mutex
+}
+
+// Creates a [FileStorage] keeping its files in [dir], which must exist.
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{dir: dir}
+}
+
+func (s *FileStorage) Open(name string) (Store, error) {
+	if name == "" || strings.ContainsAny(name, `/\:`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("Invalid store name '%s'.", name)
+	}
+	return &fileStore{storage: s, path: filepath.Join(s.dir, name+".json")}, nil
+}
+
+type fileStore struct {
+	storage *FileStorage
+	path    string
+}
+
+// Reads the values of the store, none if its file doesn't exist yet. The
+// storage must be locked.
+func (s *fileStore) read() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return values, json.Unmarshal(data, &values)
+}
+
+// Replaces the file of the store, through a temporary file so it is never
+// left half written. The storage must be locked.
+func (s *fileStore) write(values map[string]interface{}) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Runs [f] on the values of the store, writing them back if it changed them.
+func (s *fileStore) update(f func(values map[string]interface{}) bool) error {
+	s.storage.mu.Lock()
+	defer s.storage.mu.Unlock()
+
+	values, err := s.read()
+	if err != nil {
+		return err
+	}
+	if f(values) {
+		return s.write(values)
+	}
+	return nil
+}
+
+func (s *fileStore) Get(key string) (value interface{}, ok bool, err error) {
+	err = s.update(func(values map[string]interface{}) bool {
+		value, ok = values[key]
+		return false
+	})
+	return value, ok, err
+}
+
+func (s *fileStore) Set(key string, value interface{}) error {
+	return s.update(func(values map[string]interface{}) bool {
+		values[key] = value
+		return true
+	})
+}
+
+func (s *fileStore) Delete(key string) error {
+	return s.update(func(values map[string]interface{}) bool {
+		_, ok := values[key]
+		delete(values, key)
+		return ok
+	})
+}
+
+func (s *fileStore) Keys() (keys []string, err error) {
+	err = s.update(func(values map[string]interface{}) bool {
+		keys = sortedKeys(values)
+		return false
+	})
+	return keys, err
+}
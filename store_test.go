@@ -0,0 +1,72 @@
+package wrengo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	for name, storage := range map[string]Storage{
+		"memory": NewMemoryStorage(),
+		"file":   NewFileStorage(t.TempDir()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			config := NewConfiguration()
+			config.Stdout = &out
+			config.Storage = storage
+			vm := NewVM(config)
+
+			assert.NoError(t, vm.Interpret(DefaultModule, `
+				import "store" for Store
+				var save = Store.open("save")
+				System.print(save.get("level"))
+				save.set("level", 1)
+				save.set("inventory", {"potions": [10, 20], "sword": true, "shield": null})
+				save.set("gone", "soon")
+				save.delete("gone")
+				System.print(save.keys)
+			`))
+			assert.Error(t, vm.Interpret(DefaultModule, `save.set("bad", {1: 2})`))
+			assert.Error(t, vm.Interpret(DefaultModule, `save.set("bad", 0/0)`))
+			assert.Error(t, vm.Interpret(DefaultModule, `save.set(1, 2)`))
+			vm.FreeVM()
+
+			// Values outlive the VM that set them.
+			vm = NewVM(config)
+			defer vm.FreeVM()
+			assert.NoError(t, vm.Interpret(DefaultModule, `
+				import "store" for Store
+				var save = Store.open("save")
+				System.print(save.get("level"))
+				var inventory = save.get("inventory")
+				System.print([inventory["potions"], inventory["sword"], inventory.containsKey("shield")])
+				System.print(Store.open("other").keys)
+			`))
+			assert.Equal(t, "null\n[inventory, level]\n1\n[[10, 20], true, true]\n[]\n", out.String())
+		})
+	}
+}
+
+func TestFileStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewFileStorage(dir)
+
+	store, err := storage.Open("save")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set("level", 2.0))
+
+	data, err := os.ReadFile(filepath.Join(dir, "save.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"level": 2}`, string(data))
+
+	for _, name := range []string{"", "../save", ".hidden", `a\b`} {
+		_, err := storage.Open(name)
+		assert.Error(t, err, name)
+	}
+}
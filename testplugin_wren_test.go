@@ -0,0 +1,31 @@
+// Code generated by wrenimpl; DO NOT EDIT.
+
+package wrengo
+
+// Implements testPlugin with the methods of a Wren object, see Implement.
+type wrenTestPlugin struct {
+	NameFn   func() string                                `wren:"name"`
+	HandleFn func(map[string]interface{}) (string, error) `wren:"handle(_)"`
+	CountsFn func([]string) (map[string]int, error)       `wren:"counts(_)"`
+	SelfFn   func() Handle                                `wren:"self"`
+}
+
+func (w wrenTestPlugin) Name() string {
+	return w.NameFn()
+}
+
+func (w wrenTestPlugin) Handle(p0 map[string]interface{}) (string, error) {
+	return w.HandleFn(p0)
+}
+
+func (w wrenTestPlugin) Counts(p0 []string) (map[string]int, error) {
+	return w.CountsFn(p0)
+}
+
+func (w wrenTestPlugin) Self() Handle {
+	return w.SelfFn()
+}
+
+func init() {
+	RegisterAdapter(func(w wrenTestPlugin) testPlugin { return w })
+}
@@ -0,0 +1,368 @@
+package wrengo
+
+import (
+	"time"
+)
+
+// The built-in "time" module, with layouts of Go's [time] package:
+//
+//	import "time" for Time, Duration
+//
+//	var start = Time.now
+//	var deadline = start + Duration.minutes(90)
+//	System.print(deadline.format("2006-01-02 15:04"))
+//	System.print(deadline.inZone("Europe/Paris"))
+//	System.print(Time.parse("2017-12-31T23:59:59Z") - start)
+//
+// `Time.now` reads the [Clock] of the configuration. Invalid layouts, values
+// and time zones abort the fiber.
+var timeModule = &Module{
+	Name: "time",
+	Source: `
+foreign class Time {
+  foreign static now
+  foreign static unix(seconds)
+
+  static parse(string) { parse(string, "2006-01-02T15:04:05Z07:00") }
+  static parse(string, layout) {
+    if (!(string is String) || !(layout is String)) {
+      Fiber.abort("Time and layout must be strings.")
+    }
+    return parse_(string, layout)
+  }
+
+  foreign static parse_(string, layout)
+
+  foreign unix
+  foreign year
+  foreign month
+  foreign day
+  foreign hour
+  foreign minute
+  foreign second
+  foreign nanosecond
+  foreign weekday
+  foreign zone
+
+  foreign utc
+  foreign inZone(name)
+  foreign format(layout)
+
+  foreign +(duration)
+  foreign -(other)
+
+  foreign <(other)
+  foreign <=(other)
+  foreign >(other)
+  foreign >=(other)
+  foreign ==(other)
+  foreign !=(other)
+
+  foreign toString
+}
+
+foreign class Duration {
+  foreign static nanoseconds(count)
+  static milliseconds(count) { nanoseconds(count * 1000000) }
+  static seconds(count) { nanoseconds(count * 1000000000) }
+  static minutes(count) { nanoseconds(count * 60000000000) }
+  static hours(count) { nanoseconds(count * 3600000000000) }
+  foreign static parse(string)
+
+  foreign nanoseconds
+  milliseconds { nanoseconds / 1000000 }
+  seconds { nanoseconds / 1000000000 }
+  minutes { nanoseconds / 60000000000 }
+  hours { nanoseconds / 3600000000000 }
+
+  foreign +(other)
+  foreign -(other)
+  foreign *(factor)
+  foreign /(divisor)
+  foreign -
+
+  foreign <(other)
+  foreign <=(other)
+  foreign >(other)
+  foreign >=(other)
+  foreign ==(other)
+  foreign !=(other)
+
+  foreign toString
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Time.now":                timeNow,
+		"static Time.unix(_)":            timeUnix,
+		"static Time.parse_(_,_)":        timeParse,
+		"Time.unix":                      timeField(func(t time.Time) float64 { return float64(t.UnixNano()) / 1e9 }),
+		"Time.year":                      timeField(func(t time.Time) float64 { return float64(t.Year()) }),
+		"Time.month":                     timeField(func(t time.Time) float64 { return float64(t.Month()) }),
+		"Time.day":                       timeField(func(t time.Time) float64 { return float64(t.Day()) }),
+		"Time.hour":                      timeField(func(t time.Time) float64 { return float64(t.Hour()) }),
+		"Time.minute":                    timeField(func(t time.Time) float64 { return float64(t.Minute()) }),
+		"Time.second":                    timeField(func(t time.Time) float64 { return float64(t.Second()) }),
+		"Time.nanosecond":                timeField(func(t time.Time) float64 { return float64(t.Nanosecond()) }),
+		"Time.weekday":                   timeField(func(t time.Time) float64 { return float64(t.Weekday()) }),
+		"Time.zone":                      timeZone,
+		"Time.utc":                       timeUTC,
+		"Time.inZone(_)":                 timeInZone,
+		"Time.format(_)":                 timeFormat,
+		"Time.+(_)":                      timeAdd,
+		"Time.-(_)":                      timeSub,
+		"Time.<(_)":                      timeCompare(func(c int) bool { return c < 0 }),
+		"Time.<=(_)":                     timeCompare(func(c int) bool { return c <= 0 }),
+		"Time.>(_)":                      timeCompare(func(c int) bool { return c > 0 }),
+		"Time.>=(_)":                     timeCompare(func(c int) bool { return c >= 0 }),
+		"Time.==(_)":                     timeEqual(true),
+		"Time.!=(_)":                     timeEqual(false),
+		"Time.toString":                  timeString,
+		"static Duration.nanoseconds(_)": durationNew,
+		"static Duration.parse(_)":       durationParse,
+		"Duration.nanoseconds":           durationNanoseconds,
+		"Duration.+(_)":                  durationArithmetic(func(a, b time.Duration) time.Duration { return a + b }),
+		"Duration.-(_)":                  durationArithmetic(func(a, b time.Duration) time.Duration { return a - b }),
+		"Duration.*(_)":                  durationScale(false),
+		"Duration./(_)":                  durationScale(true),
+		"Duration.-":                     durationNeg,
+		"Duration.<(_)":                  durationCompare(func(a, b time.Duration) bool { return a < b }),
+		"Duration.<=(_)":                 durationCompare(func(a, b time.Duration) bool { return a <= b }),
+		"Duration.>(_)":                  durationCompare(func(a, b time.Duration) bool { return a > b }),
+		"Duration.>=(_)":                 durationCompare(func(a, b time.Duration) bool { return a >= b }),
+		"Duration.==(_)":                 durationEqual(true),
+		"Duration.!=(_)":                 durationEqual(false),
+		"Duration.toString":              durationString,
+	},
+	Classes: map[string]func(*VM) interface{}{
+		"Time":     func(*VM) interface{} { return time.Time{} },
+		"Duration": func(*VM) interface{} { return time.Duration(0) },
+	},
+}
+
+// Reads the Time in [slot], reporting whether there is one.
+func slotTime(vm *VM, slot int) (time.Time, bool) {
+	if vm.GetSlotType(slot) != WREN_TYPE_FOREIGN {
+		return time.Time{}, false
+	}
+	t, ok := vm.GetSlotObject(slot).(time.Time)
+	return t, ok
+}
+
+// Reads the Duration in [slot], reporting whether there is one.
+func slotDuration(vm *VM, slot int) (time.Duration, bool) {
+	if vm.GetSlotType(slot) != WREN_TYPE_FOREIGN {
+		return 0, false
+	}
+	d, ok := vm.GetSlotObject(slot).(time.Duration)
+	return d, ok
+}
+
+func returnTime(vm *VM, t time.Time) {
+	vm.returnObject("time", "Time", t)
+}
+
+func returnDuration(vm *VM, d time.Duration) {
+	vm.returnObject("time", "Duration", d)
+}
+
+func timeNow(vm *VM) {
+	vm.SetSlotNewObject(0, 0, vm.clock.Now())
+}
+
+func timeUnix(vm *VM) {
+	if vm.GetSlotType(1) != WREN_TYPE_NUM {
+		vm.abortFiber("Seconds must be a number.")
+		return
+	}
+	vm.SetSlotNewObject(0, 0, time.Unix(0, int64(vm.GetSlotDouble(1)*1e9)).UTC())
+}
+
+func timeParse(vm *VM) {
+	t, err := time.Parse(vm.GetSlotString(2), vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNewObject(0, 0, t)
+}
+
+func timeField(field func(time.Time) float64) func(*VM) {
+	return func(vm *VM) {
+		t, _ := slotTime(vm, 0)
+		vm.SetSlotDouble(0, field(t))
+	}
+}
+
+func timeZone(vm *VM) {
+	t, _ := slotTime(vm, 0)
+	vm.SetSlotString(0, t.Location().String())
+}
+
+func timeUTC(vm *VM) {
+	t, _ := slotTime(vm, 0)
+	returnTime(vm, t.UTC())
+}
+
+func timeInZone(vm *VM) {
+	if vm.GetSlotType(1) != WREN_TYPE_STRING {
+		vm.abortFiber("Time zone must be a string.")
+		return
+	}
+	loc, err := time.LoadLocation(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	t, _ := slotTime(vm, 0)
+	returnTime(vm, t.In(loc))
+}
+
+func timeFormat(vm *VM) {
+	if vm.GetSlotType(1) != WREN_TYPE_STRING {
+		vm.abortFiber("Layout must be a string.")
+		return
+	}
+	t, _ := slotTime(vm, 0)
+	vm.SetSlotString(0, t.Format(vm.GetSlotString(1)))
+}
+
+func timeAdd(vm *VM) {
+	d, ok := slotDuration(vm, 1)
+	if !ok {
+		vm.abortFiber("Right operand must be a Duration.")
+		return
+	}
+	t, _ := slotTime(vm, 0)
+	returnTime(vm, t.Add(d))
+}
+
+// Subtracting a Duration gives a Time, and subtracting a Time the Duration
+// between both.
+func timeSub(vm *VM) {
+	t, _ := slotTime(vm, 0)
+	if d, ok := slotDuration(vm, 1); ok {
+		returnTime(vm, t.Add(-d))
+		return
+	}
+	if u, ok := slotTime(vm, 1); ok {
+		returnDuration(vm, t.Sub(u))
+		return
+	}
+	vm.abortFiber("Right operand must be a Time or a Duration.")
+}
+
+func timeCompare(test func(int) bool) func(*VM) {
+	return func(vm *VM) {
+		u, ok := slotTime(vm, 1)
+		if !ok {
+			vm.abortFiber("Right operand must be a Time.")
+			return
+		}
+		t, _ := slotTime(vm, 0)
+		vm.SetSlotBool(0, test(t.Compare(u)))
+	}
+}
+
+// Times in different zones are equal if they are the same instant.
+func timeEqual(equal bool) func(*VM) {
+	return func(vm *VM) {
+		t, _ := slotTime(vm, 0)
+		u, ok := slotTime(vm, 1)
+		vm.SetSlotBool(0, (ok && t.Equal(u)) == equal)
+	}
+}
+
+func timeString(vm *VM) {
+	t, _ := slotTime(vm, 0)
+	vm.SetSlotString(0, t.Format(time.RFC3339Nano))
+}
+
+func durationNew(vm *VM) {
+	if vm.GetSlotType(1) != WREN_TYPE_NUM {
+		vm.abortFiber("Count must be a number.")
+		return
+	}
+	vm.SetSlotNewObject(0, 0, time.Duration(vm.GetSlotDouble(1)))
+}
+
+func durationParse(vm *VM) {
+	if vm.GetSlotType(1) != WREN_TYPE_STRING {
+		vm.abortFiber("Duration must be a string.")
+		return
+	}
+	d, err := time.ParseDuration(vm.GetSlotString(1))
+	if err != nil {
+		vm.abortFiber(err.Error())
+		return
+	}
+	vm.SetSlotNewObject(0, 0, d)
+}
+
+func durationNanoseconds(vm *VM) {
+	d, _ := slotDuration(vm, 0)
+	vm.SetSlotDouble(0, float64(d))
+}
+
+func durationArithmetic(op func(a, b time.Duration) time.Duration) func(*VM) {
+	return func(vm *VM) {
+		b, ok := slotDuration(vm, 1)
+		if !ok {
+			vm.abortFiber("Right operand must be a Duration.")
+			return
+		}
+		a, _ := slotDuration(vm, 0)
+		returnDuration(vm, op(a, b))
+	}
+}
+
+func durationScale(divide bool) func(*VM) {
+	return func(vm *VM) {
+		if vm.GetSlotType(1) != WREN_TYPE_NUM {
+			vm.abortFiber("Right operand must be a number.")
+			return
+		}
+		var (
+			d, _ = slotDuration(vm, 0)
+			f    = vm.GetSlotDouble(1)
+		)
+		if divide {
+			if f == 0 {
+				vm.abortFiber("Division by zero.")
+				return
+			}
+			returnDuration(vm, time.Duration(float64(d)/f))
+			return
+		}
+		returnDuration(vm, time.Duration(float64(d)*f))
+	}
+}
+
+func durationNeg(vm *VM) {
+	d, _ := slotDuration(vm, 0)
+	returnDuration(vm, -d)
+}
+
+func durationCompare(test func(a, b time.Duration) bool) func(*VM) {
+	return func(vm *VM) {
+		b, ok := slotDuration(vm, 1)
+		if !ok {
+			vm.abortFiber("Right operand must be a Duration.")
+			return
+		}
+		a, _ := slotDuration(vm, 0)
+		vm.SetSlotBool(0, test(a, b))
+	}
+}
+
+func durationEqual(equal bool) func(*VM) {
+	return func(vm *VM) {
+		a, _ := slotDuration(vm, 0)
+		b, ok := slotDuration(vm, 1)
+		vm.SetSlotBool(0, (ok && a == b) == equal)
+	}
+}
+
+func durationString(vm *VM) {
+	d, _ := slotDuration(vm, 0)
+	vm.SetSlotString(0, d.String())
+}
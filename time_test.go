@@ -0,0 +1,50 @@
+package wrengo
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.Clock = NewFakeClock(time.Date(2017, 12, 31, 23, 0, 0, 0, time.UTC))
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "time" for Time, Duration
+		var now = Time.now
+		System.print(now)
+		System.print([now.year, now.month, now.day, now.hour, now.weekday, now.zone])
+
+		var later = now + Duration.minutes(90)
+		System.print(later.format("2006-01-02 15:04"))
+		System.print([later > now, later - now, later - Duration.hours(1.5) == now])
+		System.print(Time.parse("2018-01-01T00:00:00Z") - now)
+		System.print(Time.parse("01/02/2018", "01/02/2006").unix)
+		System.print(Time.unix(1).utc)
+
+		var d = Duration.parse("1m30s")
+		System.print([d.seconds, d * 2, d / 3, -d, d == Duration.seconds(90), d == 90])
+	`))
+	assert.Equal(t, `2017-12-31T23:00:00Z
+[2017, 12, 31, 23, 0, UTC]
+2018-01-01 00:30
+[true, 1h30m0s, true]
+1h0m0s
+1514851200
+1970-01-01T00:00:01Z
+[90, 3m0s, 30s, -1m30s, true, false]
+`, out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Time.parse("yesterday")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Time.now.inZone("Nowhere/Invalid")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Duration.parse("soon")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Time.now + 1`))
+}
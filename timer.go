@@ -0,0 +1,69 @@
+package wrengo
+
+import (
+	"context"
+	"time"
+)
+
+// The built-in "timer" module.
+//
+// Sleeping suspends only the calling fiber, other fibers keep running while
+// [VM.RunLoop] waits for the timer:
+//
+//	import "timer" for Timer
+//
+//	Timer.after(100) { System.print("later") }
+//	Timer.sleep(50)
+//	System.print("sooner")
+//
+// Time is read from the [Clock] of the configuration.
+var timerModule = &Module{
+	Name: "timer",
+	Source: `
+import "scheduler" for Scheduler
+
+class Timer {
+  static sleep(milliseconds) {
+    validate_(milliseconds)
+    startTimer_(milliseconds, Fiber.current)
+    return Scheduler.runNextScheduled_()
+  }
+
+  static after(milliseconds, fn) {
+    validate_(milliseconds)
+    if (!(fn is Fn)) Fiber.abort("Callback must be a function.")
+
+    startTimer_(milliseconds, Fiber.new {
+      fn.call()
+      Scheduler.runNextScheduled_()
+    })
+  }
+
+  static validate_(milliseconds) {
+    if (!(milliseconds is Num)) Fiber.abort("Milliseconds must be a number.")
+    if (milliseconds < 0) Fiber.abort("Milliseconds cannot be negative.")
+  }
+
+  foreign static startTimer_(milliseconds, fiber)
+}
+`,
+	Methods: map[string]func(*VM){
+		"static Timer.startTimer_(_,_)": timerStart,
+	},
+}
+
+func timerStart(vm *VM) {
+	// Started before the work runs, so a fake clock advanced right after
+	// counts from now.
+	fired := vm.clock.After(time.Duration(vm.GetSlotDouble(1) * float64(time.Millisecond)))
+
+	vm.Async(2, func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-fired:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	vm.SetSlotNull(0)
+}
@@ -0,0 +1,68 @@
+package wrengo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimerFakeClock(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.Clock = NewFakeClock(time.Unix(0, 0))
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "timer" for Timer
+		Timer.after(30) { System.print("after 30") }
+		Timer.after(10) { System.print("after 10") }
+		Timer.sleep(20)
+		System.print("slept 20")
+	`))
+	assert.Equal(t, "", out.String())
+
+	assert.NoError(t, vm.AdvanceClock(15*time.Millisecond))
+	assert.Equal(t, "after 10\n", out.String())
+
+	assert.NoError(t, vm.AdvanceClock(15*time.Millisecond))
+	assert.Equal(t, "after 10\nslept 20\nafter 30\n", out.String())
+}
+
+func TestTimerSystemClock(t *testing.T) {
+	var out bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "timer" for Timer
+		Timer.sleep(1)
+		System.print("done")
+	`))
+	assert.NoError(t, vm.RunLoop(context.Background()))
+	assert.Equal(t, "done\n", out.String())
+
+	assert.Error(t, vm.Interpret(DefaultModule, `Timer.sleep(-1)`))
+	assert.Error(t, vm.AdvanceClock(time.Second))
+}
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	first, second := clock.After(2*time.Second), clock.After(time.Second)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(1, 0), <-second)
+	assert.Len(t, first, 0)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(2, 0), <-first)
+	assert.Equal(t, time.Unix(2, 0), clock.Now())
+}
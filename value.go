@@ -0,0 +1,262 @@
+package wrengo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Tags of lists and maps encoded by the "wrengo/value" module.
+const (
+	encodedList = 0
+	encodedMap  = 1
+)
+
+// The internal "wrengo/value" module, which encodes lists and maps as lists
+// that can cross the slot API, and back.
+//
+// The slot API can't create or read maps, so both are encoded as lists tagged
+// with their kind: `[0, elements...]` or `[1, key, value, ...]`. Cyclic values
+// can't be encoded.
+var valueModule = &Module{
+	Name: "wrengo/value",
+	Source: `
+class Value {
+  static encode(value) { encode_(value, []) }
+
+  static encode_(value, stack) {
+    if (!(value is List) && !(value is Map)) return value
+
+    for (seen in stack) {
+      if (Object.same(seen, value)) Fiber.abort("Cannot encode a cyclic value.")
+    }
+    stack.add(value)
+
+    var encoded
+    if (value is List) {
+      encoded = [0]
+      for (element in value) encoded.add(encode_(element, stack))
+    } else {
+      encoded = [1]
+      for (key in value.keys) {
+        encoded.add(key)
+        encoded.add(encode_(value[key], stack))
+      }
+    }
+
+    stack.removeAt(-1)
+    return encoded
+  }
+
+  static decode(value) {
+    if (!(value is List)) return value
+
+    if (value[0] == 0) {
+      var list = []
+      for (i in 1...value.count) list.add(decode(value[i]))
+      return list
+    }
+
+    var map = {}
+    var i = 1
+    while (i < value.count) {
+      map[value[i]] = decode(value[i + 1])
+      i = i + 2
+    }
+    return map
+  }
+}
+`,
+}
+
+// Stores the Go [value] in [slot], converted to the matching Wren value.
+//
+// Nil becomes null, booleans, numbers and strings are stored as they are, a
+// []byte becomes a string of bytes, slices and arrays become lists and a
+// [Handle] becomes the object it holds. Anything else is an error.
+//
+// Elements of lists are set up in slots past the ones in use, growing the
+// stack if needed.
+func (vm *VM) SetSlotValue(slot int, value interface{}) error {
+	return vm.setSlotValue(slot, value, false)
+}
+
+// Stores the Go [value] in [slot] like [SetSlotValue], with lists and maps
+// encoded for `Value.decode(_)` of the "wrengo/value" module.
+func (vm *VM) setSlotEncoded(slot int, value interface{}) error {
+	return vm.setSlotValue(slot, value, true)
+}
+
+func (vm *VM) setSlotValue(slot int, value interface{}, encoded bool) error {
+	switch v := value.(type) {
+	case nil:
+		vm.SetSlotNull(slot)
+		return nil
+	case bool:
+		vm.SetSlotBool(slot, v)
+		return nil
+	case string:
+		vm.SetSlotString(slot, v)
+		return nil
+	case []byte:
+		vm.SetSlotBytes(slot, v)
+		return nil
+	case Handle:
+		vm.SetSlotHandle(slot, v)
+		return nil
+	case *Handle:
+		vm.SetSlotHandle(slot, *v)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		vm.SetSlotBool(slot, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vm.SetSlotDouble(slot, float64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		vm.SetSlotDouble(slot, float64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		vm.SetSlotDouble(slot, rv.Float())
+	case reflect.String:
+		vm.SetSlotString(slot, rv.String())
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			vm.SetSlotNull(slot)
+			return nil
+		}
+		return vm.setSlotValue(slot, rv.Elem().Interface(), encoded)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			vm.SetSlotNull(slot)
+			return nil
+		}
+
+		element := vm.scratchSlot()
+		vm.SetSlotNewList(slot)
+		if encoded {
+			vm.SetSlotDouble(element, encodedList)
+			vm.InsertInList(slot, -1, element)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := vm.setSlotValue(element, rv.Index(i).Interface(), encoded); err != nil {
+				return err
+			}
+			vm.InsertInList(slot, -1, element)
+		}
+	case reflect.Map:
+		if !encoded {
+			return fmt.Errorf("wrengo: cannot convert %T to a Wren value", value)
+		}
+		if rv.IsNil() {
+			vm.SetSlotNull(slot)
+			return nil
+		}
+
+		element := vm.scratchSlot()
+		vm.SetSlotNewList(slot)
+		vm.SetSlotDouble(element, encodedMap)
+		vm.InsertInList(slot, -1, element)
+		iter := rv.MapRange()
+		for iter.Next() {
+			for _, v := range []reflect.Value{iter.Key(), iter.Value()} {
+				if err := vm.setSlotValue(element, v.Interface(), encoded); err != nil {
+					return err
+				}
+				vm.InsertInList(slot, -1, element)
+			}
+		}
+	default:
+		return fmt.Errorf("wrengo: cannot convert %T to a Wren value", value)
+	}
+	return nil
+}
+
+// Reads the value in [slot] as a Go value.
+//
+// Null becomes nil, booleans, numbers and strings become bool, float64 and
+// string, and lists become []interface{}. Foreign objects and instances of
+// classes defined in Wren are an error, use [GetSlotHandle] to keep those.
+func (vm *VM) GetSlotValue(slot int) (interface{}, error) {
+	return vm.getSlotValue(slot, false)
+}
+
+// Reads the value in [slot] like [GetSlotValue], with lists and maps encoded by
+// `Value.encode(_)` of the "wrengo/value" module. Maps become
+// map[interface{}]interface{}.
+func (vm *VM) getSlotEncoded(slot int) (interface{}, error) {
+	return vm.getSlotValue(slot, true)
+}
+
+func (vm *VM) getSlotValue(slot int, encoded bool) (interface{}, error) {
+	switch t := vm.GetSlotType(slot); t {
+	case WREN_TYPE_NULL:
+		return nil, nil
+	case WREN_TYPE_BOOL:
+		return vm.GetSlotBool(slot), nil
+	case WREN_TYPE_NUM:
+		return vm.GetSlotDouble(slot), nil
+	case WREN_TYPE_STRING:
+		return vm.GetSlotString(slot), nil
+	case WREN_TYPE_LIST:
+		var (
+			count   = vm.GetListCount(slot)
+			element = vm.scratchSlot()
+			first   = 0
+		)
+		if encoded {
+			if count == 0 {
+				return nil, errors.New("wrengo: cannot decode an empty list")
+			}
+			vm.GetListElement(slot, 0, element)
+			if vm.GetSlotType(element) == WREN_TYPE_NUM && vm.GetSlotDouble(element) == encodedMap {
+				return vm.getSlotMap(slot, element)
+			}
+			first = 1
+		}
+
+		list := make([]interface{}, 0, count-first)
+		for i := first; i < count; i++ {
+			vm.GetListElement(slot, i, element)
+			v, err := vm.getSlotValue(element, encoded)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("wrengo: cannot convert %s to a Go value", t)
+	}
+}
+
+// Reads the map encoded in the list in [slot], using [element] for its keys
+// and values.
+func (vm *VM) getSlotMap(slot, element int) (map[interface{}]interface{}, error) {
+	var (
+		count = vm.GetListCount(slot)
+		m     = make(map[interface{}]interface{}, count/2)
+	)
+	for i := 1; i+1 < count; i += 2 {
+		vm.GetListElement(slot, i, element)
+		key, err := vm.getSlotValue(element, true)
+		if err != nil {
+			return nil, err
+		}
+		vm.GetListElement(slot, i+1, element)
+		value, err := vm.getSlotValue(element, true)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+// Returns a slot past the ones in use, for temporary values.
+func (vm *VM) scratchSlot() int {
+	slot := vm.GetSlotCount()
+	vm.EnsureSlots(slot + 1)
+	return slot
+}
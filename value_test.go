@@ -0,0 +1,35 @@
+package wrengo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotValue(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	vm.EnsureSlots(1)
+	assert.NoError(t, vm.SetSlotValue(0, []interface{}{1, "a", true, nil, []int{2, 3}}))
+
+	v, err := vm.GetSlotValue(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, "a", true, nil, []interface{}{2.0, 3.0}}, v)
+
+	assert.Error(t, vm.SetSlotValue(0, struct{}{}))
+}
+
+func TestSlotEncoded(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	vm.EnsureSlots(1)
+	assert.NoError(t, vm.setSlotEncoded(0, map[string]interface{}{"a": []int{1}}))
+
+	v, err := vm.getSlotEncoded(0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"a": []interface{}{1.0}}, v)
+
+	assert.Error(t, vm.SetSlotValue(0, map[string]int{}))
+}
@@ -3,6 +3,7 @@ package wrengo
 /*
 #cgo LDFLAGS: -L${SRCDIR}/lib -lwren
 #include "wren.h"
+#include "heap.h"
 
 extern char* wrengoResolveModule(WrenVM*, char*, char*);
 extern char* wrengoLoadModule(WrenVM*, char*);
@@ -15,7 +16,10 @@ import "C"
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"log/slog"
 	"reflect"
+	"sync"
 	"unsafe"
 )
 
@@ -24,9 +28,18 @@ const (
 )
 
 var (
-	vmMap = make(map[*C.WrenVM]*VM)
+	vmMap      = make(map[*C.WrenVM]*VM)
+	vmMapGuard sync.RWMutex
 )
 
+// Returns the VM wrapping [vm]. VMs are created and freed from any goroutine,
+// so the map is never accessed directly.
+func lookupVM(vm *C.WrenVM) *VM {
+	vmMapGuard.RLock()
+	defer vmMapGuard.RUnlock()
+	return vmMap[vm]
+}
+
 type Callbacks struct {
 
 	// The callback Wren uses to resolve a module name.
@@ -69,20 +82,27 @@ type Callbacks struct {
 	// previous source and not call this.
 	//
 	// If a module with the given name could not be found by the embedder, it
-	// should return NULL and Wren will report that as a runtime error.
+	// should return an empty string and Wren will report that as a runtime
+	// error.
+	//
+	// Modules registered with [RegisterModule] never reach this. Built-in
+	// modules, like "json" or "timer", are only loaded when this doesn't
+	// provide a module of the same name. A module replacing "scheduler" also
+	// replaces it for the built-in modules importing it.
 	LoadModuleFunc func(vm *VM, name string) string
 
 	// The callback Wren uses to display text when `System.print()`
 	// or the other related functions are called.
 	//
-	// If this is `NULL`, Wrengo by default writes into Stdout.
+	// If this is `NULL`, Wrengo writes the text into [Stdout] of the
+	// configuration.
 	WriteFunc func(vm *VM, text string)
 
 	// The callback Wren uses to report errors.
 	//
 	// When an error occurs, this will be called with the module name, line
-	// number, and an error message. If this is `NULL`,
-	// Wrengo by default writes errors into Stdout.
+	// number, and an error message. If this is `NULL`, Wrengo writes errors
+	// into [Stderr] of the configuration, formatted like [CallbackError].
 	ErrorFunc func(vm *VM, errorType ErrorType, module string, line int, message string)
 }
 
@@ -123,6 +143,66 @@ type Configuration struct {
 	// If zero, defaults to 50.
 	HeapGrowthPercent int
 
+	// The writer that receives the text of `System.print()` and the other
+	// related functions when [WriteFunc] is `NULL`.
+	//
+	// If both are `NULL`, the text is discarded.
+	Stdout io.Writer
+
+	// The writer that receives errors when [ErrorFunc] is `NULL`.
+	//
+	// If both are `NULL`, errors are only reported through the result of
+	// [Interpret] and [Call].
+	Stderr io.Writer
+
+	// Panic when the VM is entered from a goroutine while another one is
+	// still inside it, instead of corrupting it.
+	//
+	// Finding the current goroutine is slow, so this is meant for debugging.
+	// Use an [Executor] to share a VM between goroutines.
+	DetectConcurrentUse bool
+
+	// The logger the built-in "log" module writes to.
+	//
+	// If this is `NULL`, [slog.Default] is used.
+	Logger *slog.Logger
+
+	// The clock the built-in "timer" module sleeps on.
+	//
+	// If this is `NULL`, [SystemClock] is used. Use a [FakeClock] and
+	// [AdvanceClock] for deterministic tests.
+	Clock Clock
+
+	// Enables the built-in "io" module, letting scripts access files.
+	//
+	// If this is `NULL`, scripts can't import the module.
+	IO *IOConfig
+
+	// Enables the built-in "os" module, letting scripts see their arguments
+	// and environment.
+	//
+	// If this is `NULL`, scripts can't import the module.
+	OS *OSConfig
+
+	// Enables the built-in "http" module, letting scripts send requests.
+	//
+	// If this is `NULL`, scripts can't import the module.
+	HTTP *HTTPConfig
+
+	// Enables the built-in "process" module, letting scripts run commands.
+	//
+	// If this is `NULL`, scripts can't import the module.
+	Process *ProcessConfig
+
+	// Enables the built-in "store" module, letting scripts keep values between
+	// runs. See [NewMemoryStorage] and [NewFileStorage].
+	//
+	// If this is `NULL`, scripts can't import the module.
+	Storage Storage
+
+	// The actor the VM runs, enabling the built-in "actor" module.
+	actor *Actor
+
 	config *C.WrenConfiguration
 }
 
@@ -139,16 +219,68 @@ func NewConfiguration() Configuration {
 // here.
 type VM struct {
 	cb               Callbacks
+	out              *output
+	logger           *slog.Logger
+	clock            Clock
 	classes, methods map[string]unsafe.Pointer
+	modules          map[string]*Module
+	builtins         map[string]*Module
+	loaded           map[string]bool
+	sources          map[string]string
+	calls            map[string]*Handle
+	events           map[string][]Handle
+	fns              map[*Fn]struct{}
+	state            *vmState
+	loop             *loop
+	files            *files
+	os               *OSConfig
+	process          *ProcessConfig
+	http             *httpClient
+	storage          Storage
+	actor            *Actor
+	heap             *C.wrengoHeap
 	vm               *C.WrenVM
 }
 
+// State of a VM that changes while it runs, shared between all copies of it.
+type vmState struct {
+	// Set once a script running in the VM hits a runtime error.
+	failed bool
+
+	// Whether [enter] checks that the VM is used by one goroutine at a time.
+	guard bool
+
+	// The goroutine currently inside the VM, and how deep, while guarded.
+	owner int64
+	depth int
+
+	// The exit code the script asked for with `Process.exit(_)`, until it is
+	// returned as an [ExitError].
+	exit *int
+
+	// Called once a runtime error and its whole stack trace were reported.
+	reported []func()
+}
+
+// The writers a VM sends its text to.
+//
+// It is shared between all copies of the VM, so redirecting the output
+// through one of them is seen by the callbacks.
+type output struct {
+	stdout, stderr io.Writer
+
+	// Receives the text instead of the callbacks while the output of a single
+	// call is captured.
+	capture io.Writer
+}
+
 // Creates a new Wren virtual machine using the given [configuration].
 func NewVM(cfg Configuration) VM {
 	cfg.config.initialHeapSize = C.size_t(cfg.InitialHeapSize)
 	cfg.config.minHeapSize = C.size_t(cfg.MinHeapSize)
 	cfg.config.heapGrowthPercent = C.int(cfg.HeapGrowthPercent)
 
+	cfg.config.reallocateFn = C.WrenReallocateFn(C.wrengoReallocate)
 	cfg.config.bindForeignMethodFn = C.WrenBindForeignMethodFn(C.wrengoBindForeignMethod)
 	cfg.config.bindForeignClassFn = C.WrenBindForeignClassFn(C.wrengoBindForeignClass)
 
@@ -156,46 +288,189 @@ func NewVM(cfg Configuration) VM {
 		cfg.config.resolveModuleFn = C.WrenResolveModuleFn(C.wrengoResolveModule)
 	}
 
-	if cfg.LoadModuleFunc != nil {
-		cfg.config.loadModuleFn = C.WrenLoadModuleFn(C.wrengoLoadModule)
-	}
+	// Registered modules are loaded through the same callback.
+	cfg.config.loadModuleFn = C.WrenLoadModuleFn(C.wrengoLoadModule)
 
-	if cfg.WriteFunc != nil {
-		cfg.config.writeFn = C.WrenWriteFn(C.wrengoWrite)
-	}
+	// Output can be captured per call, so the write callback is always set.
+	cfg.config.writeFn = C.WrenWriteFn(C.wrengoWrite)
 
-	if cfg.ErrorFunc != nil {
+	if cfg.ErrorFunc != nil || cfg.Stderr != nil {
 		cfg.config.errorFn = C.WrenErrorFn(C.wrengoError)
 	}
 
 	vm := VM{}
-	vm.vm = C.wrenNewVM(cfg.config)
+	vm.heap = (*C.wrengoHeap)(C.calloc(1, C.sizeof_wrengoHeap))
+	vm.vm = C.wrengoNewVM(vm.heap, cfg.config)
 	vm.classes = make(map[string]unsafe.Pointer)
 	vm.methods = make(map[string]unsafe.Pointer)
 	vm.cb = cfg.Callbacks
+	vm.out = &output{stdout: cfg.Stdout, stderr: cfg.Stderr}
+	vm.modules = make(map[string]*Module)
+	vm.builtins = make(map[string]*Module)
+	vm.loaded = make(map[string]bool)
+	vm.sources = make(map[string]string)
+	vm.calls = make(map[string]*Handle)
+	vm.events = make(map[string][]Handle)
+	vm.fns = make(map[*Fn]struct{})
+	vm.state = &vmState{guard: cfg.DetectConcurrentUse}
+	vm.loop = newLoop()
+	vm.clock = cfg.Clock
+	if vm.clock == nil {
+		vm.clock = SystemClock
+	}
+	vm.logger = cfg.Logger
+	if vm.logger == nil {
+		vm.logger = slog.Default()
+	}
+
+	vmMapGuard.Lock()
 	vmMap[vm.vm] = &vm
+	vmMapGuard.Unlock()
+
+	for _, m := range builtinModules {
+		vm.offer(m)
+	}
+	if cfg.IO != nil {
+		vm.files = newFiles(*cfg.IO)
+		vm.offer(ioModule)
+	}
+	if cfg.OS != nil {
+		os := *cfg.OS
+		vm.os = &os
+		vm.offer(osModule)
+	}
+	if cfg.HTTP != nil {
+		vm.http = newHTTPClient(*cfg.HTTP)
+		vm.offer(httpModule)
+	}
+	if cfg.Process != nil {
+		process := *cfg.Process
+		vm.process = &process
+		vm.offer(processModule)
+	}
+	if cfg.Storage != nil {
+		vm.storage = cfg.Storage
+		vm.offer(storeModule)
+	}
+	if cfg.actor != nil {
+		vm.actor = cfg.actor
+		vm.offer(actorModule)
+	}
 	return vm
 }
 
+// Modules every VM can import.
+var builtinModules = []*Module{
+	logModule,
+	schedulerModule,
+	timerModule,
+	fiberModule,
+	valueModule,
+	jsonModule,
+	regexModule,
+	bignumModule,
+	timeModule,
+	cryptoModule,
+	encodingModule,
+	channelModule,
+	eventsModule,
+	goModule,
+}
+
 // Disposes of all resources is use by [vm], which was previously created by a
 // call to [NewVM].
 func (vm *VM) FreeVM() {
+	defer vm.enter()()
+	vm.loop.free()
+	for _, h := range vm.calls {
+		h.Release()
+	}
+	for _, handlers := range vm.events {
+		for _, h := range handlers {
+			h.Release()
+		}
+	}
+	for fn := range vm.fns {
+		fn.Release()
+	}
+	if vm.files != nil {
+		vm.files.close()
+	}
 	C.wrenFreeVM(vm.vm)
+	C.free(unsafe.Pointer(vm.heap))
+
+	vmMapGuard.Lock()
 	delete(vmMap, vm.vm)
+	vmMapGuard.Unlock()
 }
 
 // Immediately run the garbage collector to free unused memory.
 func (vm *VM) GC() {
+	defer vm.enter()()
 	C.wrenCollectGarbage(vm.vm)
 }
 
+// Returns the number of bytes Wren currently holds for objects allocated while
+// the VM was created or running [Interpret] and [Call].
+//
+// Memory for values the host stores in slots outside of those calls is not
+// accounted.
+func (vm *VM) BytesAllocated() int {
+	return int(vm.heap.bytes)
+}
+
+// Calls [f] once the error callback was given the runtime error being
+// reported and every frame of its stack trace.
+func (vm *VM) onReported(f func()) {
+	vm.state.reported = append(vm.state.reported, f)
+}
+
+// Records the result of running code in the VM.
+func (vm *VM) result(r C.WrenInterpretResult) error {
+	if InterpretResult(r) == RESULT_RUNTIME_ERROR {
+		vm.state.failed = true
+		reported := vm.state.reported
+		vm.state.reported = nil
+		for _, f := range reported {
+			f()
+		}
+	}
+	if code := vm.state.exit; code != nil {
+		vm.state.exit = nil
+		return &ExitError{Code: *code}
+	}
+	return InterpretResult(r).Error()
+}
+
 // Runs [source], a string of Wren source code in a new fiber in VM in the
 // context of resolved [module].
+//
+// The source is kept until the module is interpreted again, so errors can be
+// rendered with it by [Diagnostics].
 func (vm *VM) Interpret(module, source string) error {
+	defer vm.enter()()
+	vm.sources[module] = source
 	m, s := C.CString(module), C.CString(source)
 	defer C.free(unsafe.Pointer(m))
 	defer C.free(unsafe.Pointer(s))
-	return InterpretResult(C.wrenInterpret(vm.vm, m, s)).Error()
+	return vm.result(C.wrengoInterpret(vm.heap, vm.vm, m, s))
+}
+
+// Runs [f] with the text of `System.print()` and the other related functions
+// redirected into [w], bypassing [WriteFunc] and [Stdout] of the
+// configuration. The previous output is restored when [f] returns.
+func (vm *VM) WithOutput(w io.Writer, f func() error) error {
+	prev := vm.out.capture
+	vm.out.capture = w
+	defer func() { vm.out.capture = prev }()
+	return f()
+}
+
+// Runs [source] like [Interpret], writing everything it prints into [w].
+func (vm *VM) InterpretTo(w io.Writer, module, source string) error {
+	return vm.WithOutput(w, func() error {
+		return vm.Interpret(module, source)
+	})
 }
 
 // A handle to a Wren object.
@@ -217,11 +492,24 @@ type Handle struct {
 // When you are done with this handle, it must be released using
 // [ReleaseHandle].
 func (vm *VM) NewCallHandle(signature string) Handle {
+	defer vm.enter()()
 	s := C.CString(signature)
 	defer C.free(unsafe.Pointer(s))
 	return Handle{vm: vm, handle: C.wrenMakeCallHandle(vm.vm, s)}
 }
 
+// Returns a call handle for [signature] kept until the VM is freed, for
+// methods the package calls over and over.
+func (vm *VM) callHandle(signature string) *Handle {
+	h, ok := vm.calls[signature]
+	if !ok {
+		handle := vm.NewCallHandle(signature)
+		h = &handle
+		vm.calls[signature] = h
+	}
+	return h
+}
+
 // Calls method, using the receiver and arguments previously set up on the
 // stack.
 //
@@ -233,17 +521,25 @@ func (vm *VM) NewCallHandle(signature string) Handle {
 //
 // After this returns, you can access the return value from slot 0 on the stack.
 func (h *Handle) Call() error {
-	return InterpretResult(C.wrenCall(h.vm.vm, h.handle)).Error()
+	defer h.vm.enter()()
+	return h.vm.result(C.wrengoCall(h.vm.heap, h.vm.vm, h.handle))
+}
+
+// Calls method like [Call], writing everything it prints into [w].
+func (h *Handle) CallTo(w io.Writer) error {
+	return h.vm.WithOutput(w, h.Call)
 }
 
 // Releases the reference stored in [handle]. After calling this, [handle] can
 // no longer be used.
 func (h *Handle) Release() {
+	defer h.vm.enter()()
 	C.wrenReleaseHandle(h.vm.vm, h.handle)
 }
 
 // Returns the number of slots available to the current foreign method.
 func (vm *VM) GetSlotCount() int {
+	defer vm.enter()()
 	return int(C.wrenGetSlotCount(vm.vm))
 }
 
@@ -254,11 +550,13 @@ func (vm *VM) GetSlotCount() int {
 //
 // It is an error to call this from a finalizer.
 func (vm *VM) EnsureSlots(numSlots int) {
+	defer vm.enter()()
 	C.wrenEnsureSlots(vm.vm, C.int(numSlots))
 }
 
 // Gets the type of the object in [slot].
 func (vm *VM) GetSlotType(slot int) WrenType {
+	defer vm.enter()()
 	return WrenType(C.wrenGetSlotType(vm.vm, C.int(slot)))
 }
 
@@ -266,29 +564,32 @@ func (vm *VM) GetSlotType(slot int) WrenType {
 //
 // It is an error to call this if the slot does not contain a boolean value.
 func (vm *VM) GetSlotBool(slot int) bool {
+	defer vm.enter()()
 	return bool(C.wrenGetSlotBool(vm.vm, C.int(slot)))
 }
 
 // Reads a byte array from [slot].
 //
-// The memory for the returned string is owned by Wren. You can inspect it
-// while in your foreign method, but cannot keep a pointer to it after the
-// function returns, since the garbage collector may reclaim it.
-//
-// Returns a pointer to the first byte of the array and fill [length] with the
-// number of bytes in the array.
+// The bytes are copied out of Wren's heap, so they may contain null bytes and
+// can be kept after the foreign method returns. At most [length] bytes are
+// read, or the whole string if [length] is zero.
 //
 // It is an error to call this if the slot does not contain a string.
 func (vm *VM) GetSlotBytes(slot, length int) []byte {
-	l := C.int(length)
-	data := C.GoString(C.wrenGetSlotBytes(vm.vm, C.int(slot), &l))
-	return []byte(data)
+	defer vm.enter()()
+	var l C.int
+	data := C.wrenGetSlotBytes(vm.vm, C.int(slot), &l)
+	if length > 0 && int(l) > length {
+		l = C.int(length)
+	}
+	return C.GoBytes(unsafe.Pointer(data), l)
 }
 
 // Reads a number from [slot].
 //
 // It is an error to call this if the slot does not contain a number.
 func (vm *VM) GetSlotDouble(slot int) float64 {
+	defer vm.enter()()
 	return float64(C.wrenGetSlotDouble(vm.vm, C.int(slot)))
 }
 
@@ -298,6 +599,7 @@ func (vm *VM) GetSlotDouble(slot int) float64 {
 // It is an error to call this if the slot does not contain an instance of a
 // foreign class.
 func (vm *VM) GetSlotForeign(slot int, i interface{}) interface{} {
+	defer vm.enter()()
 	ptr := C.wrenGetSlotForeign(vm.vm, C.int(slot)) // ptr
 	return reflect.NewAt(reflect.TypeOf(i), ptr).Elem().Interface()
 }
@@ -310,6 +612,7 @@ func (vm *VM) GetSlotForeign(slot int, i interface{}) interface{} {
 //
 // It is an error to call this if the slot does not contain a string.
 func (vm *VM) GetSlotString(slot int) string {
+	defer vm.enter()()
 	return C.GoString(C.wrenGetSlotString(vm.vm, C.int(slot)))
 }
 
@@ -318,11 +621,13 @@ func (vm *VM) GetSlotString(slot int) string {
 // This will prevent the object that is referred to from being garbage collected
 // until the handle is released by calling [wrenReleaseHandle()].
 func (vm *VM) GetSlotHandle(slot int) Handle {
+	defer vm.enter()()
 	return Handle{vm: vm, handle: C.wrenGetSlotHandle(vm.vm, C.int(slot))}
 }
 
 // Stores the boolean [value] in [slot].
 func (vm *VM) SetSlotBool(slot int, value bool) {
+	defer vm.enter()()
 	C.wrenSetSlotBool(vm.vm, C.int(slot), C.bool(value))
 }
 
@@ -331,23 +636,27 @@ func (vm *VM) SetSlotBool(slot int, value bool) {
 // The bytes are copied to a new string within Wren's heap, so you can free
 // memory used by them after this is called.
 func (vm *VM) SetSlotBytes(slot int, value []byte) {
-	val := C.CString(string(value))
-	defer C.free(unsafe.Pointer(val))
-	C.wrenSetSlotBytes(vm.vm, C.int(slot), val, C.size_t(len(value)))
+	defer vm.enter()()
+	val := C.CBytes(value)
+	defer C.free(val)
+	C.wrenSetSlotBytes(vm.vm, C.int(slot), (*C.char)(val), C.size_t(len(value)))
 }
 
 // Stores the numeric [value] in [slot].
 func (vm *VM) SetSlotDouble(slot int, value float64) {
+	defer vm.enter()()
 	C.wrenSetSlotDouble(vm.vm, C.int(slot), C.double(value))
 }
 
 // Stores a new empty list in [slot].
 func (vm *VM) SetSlotNewList(slot int) {
+	defer vm.enter()()
 	C.wrenSetSlotNewList(vm.vm, C.int(slot))
 }
 
 // Stores null in [slot].
 func (vm *VM) SetSlotNull(slot int) {
+	defer vm.enter()()
 	C.wrenSetSlotNull(vm.vm, C.int(slot))
 }
 
@@ -358,6 +667,7 @@ func (vm *VM) SetSlotNull(slot int) {
 // [strlen()]. If the string may contain any null bytes in the middle, then you
 // should use [wrenSetSlotBytes()] instead.
 func (vm *VM) SetSlotString(slot int, value string) {
+	defer vm.enter()()
 	val := C.CString(value)
 	defer C.free(unsafe.Pointer(val))
 	C.wrenSetSlotString(vm.vm, C.int(slot), val)
@@ -367,17 +677,20 @@ func (vm *VM) SetSlotString(slot int, value string) {
 //
 // This does not release the handle for the value.
 func (vm *VM) SetSlotHandle(slot int, handle Handle) {
+	defer vm.enter()()
 	C.wrenSetSlotHandle(vm.vm, C.int(slot), handle.handle)
 }
 
 // Returns the number of elements in the list stored in [slot].
 func (vm *VM) GetListCount(slot int) int {
+	defer vm.enter()()
 	return int(C.wrenGetListCount(vm.vm, C.int(slot)))
 }
 
 // Reads element [index] from the list in [listSlot] and stores it in
 // [elementSlot].
 func (vm *VM) GetListElement(listSlot, index, elementSlot int) {
+	defer vm.enter()()
 	C.wrenGetListElement(vm.vm, C.int(listSlot), C.int(index), C.int(elementSlot))
 }
 
@@ -387,12 +700,14 @@ func (vm *VM) GetListElement(listSlot, index, elementSlot int) {
 // As in Wren, negative indexes can be used to insert from the end. To append
 // an element, use `-1` for the index.
 func (vm *VM) InsertInList(listSlot, index, elementSlot int) {
+	defer vm.enter()()
 	C.wrenInsertInList(vm.vm, C.int(listSlot), C.int(index), C.int(elementSlot))
 }
 
 // Looks up the top level variable with [name] in resolved [module] and stores
 // it in [slot].
 func (vm *VM) GetVariable(module, name string, slot int) {
+	defer vm.enter()()
 	m, n := C.CString(module), C.CString(name)
 	defer C.free(unsafe.Pointer(m))
 	defer C.free(unsafe.Pointer(n))
@@ -402,16 +717,23 @@ func (vm *VM) GetVariable(module, name string, slot int) {
 // Sets the current fiber to be aborted, and uses the value in [slot] as the
 // runtime error object.
 func (vm *VM) AbortFiber(slot int) {
+	defer vm.enter()()
 	C.wrenAbortFiber(vm.vm, C.int(slot))
 }
 
+// Aborts the current fiber with the error [message], from a foreign method.
+func (vm *VM) abortFiber(message string) {
+	vm.SetSlotString(0, message)
+	vm.AbortFiber(0)
+}
+
 // Registers a foreign method in main module with the virtual machine.
 func (vm *VM) BindForeignMethod(class string, isStatic bool, signature string, f func(*VM)) error {
 	ptr, err := registerFunc(signature, f)
 	if err != nil {
 		return err
 	}
-	vmMap[vm.vm].methods[bindSignature(DefaultModule, class, isStatic, signature)] = ptr
+	vm.methods[bindSignature(DefaultModule, class, isStatic, signature)] = ptr
 	return nil
 }
 
@@ -423,7 +745,7 @@ func (vm *VM) BindForeignClass(signature string, f func() interface{}) error {
 	if err != nil {
 		return err
 	}
-	vmMap[vm.vm].classes[signature] = ptr
+	vm.classes[signature] = ptr
 	return nil
 }
 
@@ -453,34 +775,71 @@ func newForeign(vm *C.WrenVM, x interface{}) {
 	reflect.NewAt(t, ptr).Elem().Set(v)
 }
 
+// Strings returned from the resolve and load callbacks are owned by Wren,
+// which frees them with its reallocate function.
+func wrenString(s string) *C.char {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return C.wrengoStrdup(cs)
+}
+
 //export wrengoResolveModule
 func wrengoResolveModule(vm *C.WrenVM, importer *C.char, name *C.char) *C.char {
-	path := C.CString(vmMap[vm].cb.ResolveModuleFunc(vmMap[vm], C.GoString(importer), C.GoString(name)))
-	defer C.free(unsafe.Pointer(path))
-	return path
+	v := lookupVM(vm)
+	n := C.GoString(name)
+	if m, ok := v.modules[n]; ok && m != v.builtins[n] {
+		return wrenString(n)
+	}
+
+	r := v.cb.ResolveModuleFunc(v, C.GoString(importer), n)
+	if _, ok := v.builtins[n]; ok && r != n && !v.provides(r) {
+		// The built-in module, unless the resolved name is a module of the
+		// host.
+		return wrenString(n)
+	}
+	return wrenString(r)
 }
 
 //export wrengoLoadModule
 func wrengoLoadModule(vm *C.WrenVM, name *C.char) *C.char {
-	code := C.CString(vmMap[vm].cb.LoadModuleFunc(vmMap[vm], C.GoString(name)))
-	defer C.free(unsafe.Pointer(code))
-	return code
-}
+	v := lookupVM(vm)
+	n := C.GoString(name)
+	if m, ok := v.modules[n]; ok {
+		v.loaded[n] = true
+		v.sources[n] = m.Source
+		return wrenString(m.Source)
+	}
 
-//export wrengoBindForeignMethod
-func wrengoBindForeignMethod(vm *C.WrenVM, module *C.char, class *C.char, static C.bool, sign *C.char) unsafe.Pointer {
-	m := C.GoString(module)
-	if m != "main" {
-		return unsafe.Pointer(nil)
+	if v.cb.LoadModuleFunc != nil {
+		if code := v.cb.LoadModuleFunc(v, n); code != "" {
+			v.sources[n] = code
+			return wrenString(code)
+		}
 	}
 
+	if m, ok := v.builtins[n]; ok {
+		v.modules[n] = m
+		v.loaded[n] = true
+		v.sources[n] = m.Source
+		return wrenString(m.Source)
+	}
+	return nil
+}
+
+//export wrengoBindForeignMethod
+func wrengoBindForeignMethod(vm *C.WrenVM, module *C.char, class *C.char, isStaticMethod C.bool, sign *C.char) unsafe.Pointer {
 	var (
+		m         = C.GoString(module)
 		className = C.GoString(class)
-		isStatic  = bool(static)
+		isStatic  = bool(isStaticMethod)
 		signature = C.GoString(sign)
 	)
 
-	if f, ok := vmMap[vm].methods[bindSignature(m, className, isStatic, signature)]; ok {
+	if m != DefaultModule {
+		return lookupVM(vm).moduleMethod(m, bindSignature(m, className, isStatic, signature))
+	}
+
+	if f, ok := lookupVM(vm).methods[bindSignature(m, className, isStatic, signature)]; ok {
 		return f
 	}
 
@@ -490,12 +849,19 @@ func wrengoBindForeignMethod(vm *C.WrenVM, module *C.char, class *C.char, static
 //export wrengoBindForeignClass
 func wrengoBindForeignClass(vm *C.WrenVM, module *C.char, className *C.char) C.WrenForeignClassMethods {
 	m := C.GoString(module)
-	if m != "main" {
-		panic("tried to bind foreign class from non-main module")
+	cn := C.GoString(className)
+	if m != DefaultModule {
+		if c := lookupVM(vm).moduleClass(m, cn); c != nil {
+			return C.WrenForeignClassMethods{
+				allocate: C.WrenForeignMethodFn(c),
+				finalize: finalizeObject,
+			}
+		}
+		// Wren reports constructing the class as a runtime error.
+		return C.WrenForeignClassMethods{}
 	}
 
-	cn := C.GoString(className)
-	if c, ok := vmMap[vm].classes[cn]; ok {
+	if c, ok := lookupVM(vm).classes[cn]; ok {
 		// Might be a good idea to support finalizers, but since this is Go,
 		// I don't think they're actually necessary.
 		return C.WrenForeignClassMethods{
@@ -509,12 +875,25 @@ func wrengoBindForeignClass(vm *C.WrenVM, module *C.char, className *C.char) C.W
 
 //export wrengoWrite
 func wrengoWrite(vm *C.WrenVM, text *C.char) {
-	vmMap[vm].cb.WriteFunc(vmMap[vm], C.GoString(text))
+	v := lookupVM(vm)
+	switch {
+	case v.out.capture != nil:
+		io.WriteString(v.out.capture, C.GoString(text))
+	case v.cb.WriteFunc != nil:
+		v.cb.WriteFunc(v, C.GoString(text))
+	case v.out.stdout != nil:
+		io.WriteString(v.out.stdout, C.GoString(text))
+	}
 }
 
 //export wrengoError
 func wrengoError(vm *C.WrenVM, err C.WrenErrorType, module *C.char, line C.int, message *C.char) {
-	vmMap[vm].cb.ErrorFunc(vmMap[vm], ErrorType(err), C.GoString(module), int(line), C.GoString(message))
+	v := lookupVM(vm)
+	if v.cb.ErrorFunc != nil {
+		v.cb.ErrorFunc(v, ErrorType(err), C.GoString(module), int(line), C.GoString(message))
+		return
+	}
+	fmt.Fprintln(v.out.stderr, formatError(ErrorType(err), C.GoString(module), int(line), C.GoString(message)))
 }
 
 // Change 256 to a different number to enable more foreign class/method registrations.
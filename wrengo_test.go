@@ -1,6 +1,7 @@
 package wrengo
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,68 @@ func TestInterpret(t *testing.T) {
 	assert.Equal(t, "Hello there!\n", out)
 }
 
+func TestStdoutStderr(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &stdout
+	config.Stderr = &stderr
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `System.print("out")`))
+	assert.Error(t, vm.Interpret(DefaultModule, `Fiber.abort("oops")`))
+
+	assert.Equal(t, "out\n", stdout.String())
+	assert.Contains(t, stderr.String(), "ERROR_RUNTIME")
+	assert.Contains(t, stderr.String(), "oops")
+}
+
+func TestInterpretTo(t *testing.T) {
+	var stdout, captured bytes.Buffer
+
+	config := NewConfiguration()
+	config.Stdout = &stdout
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	assert.NoError(t, vm.InterpretTo(&captured, DefaultModule, `
+		class Greeter {
+			static greet(name) {
+				System.print("Hello, %(name)!")
+			}
+		}
+		System.print("captured")
+	`))
+	assert.Equal(t, "captured\n", captured.String())
+
+	captured.Reset()
+	vm.EnsureSlots(2)
+	vm.GetVariable(DefaultModule, "Greeter", 0)
+	vm.SetSlotString(1, "Wren")
+	h := vm.NewCallHandle("greet(_)")
+	defer h.Release()
+	assert.NoError(t, h.CallTo(&captured))
+	assert.Equal(t, "Hello, Wren!\n", captured.String())
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `System.print("not captured")`))
+	assert.Equal(t, "not captured\n", stdout.String())
+}
+
+func TestBytesAllocated(t *testing.T) {
+	vm := NewVM(NewConfiguration())
+	defer vm.FreeVM()
+
+	before := vm.BytesAllocated()
+	assert.Greater(t, before, 0)
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		var list = []
+		for (i in 0...10000) list.add("item %(i)")
+	`))
+	assert.Greater(t, vm.BytesAllocated(), before)
+}
+
 func TestCallHandle(t *testing.T) {
 	config := NewConfiguration()
 	config.WriteFunc = CallbackWrite
@@ -80,6 +143,8 @@ func TestSlots(t *testing.T) {
 
 	vm.SetSlotBytes(0, []byte("Hello"))
 	assert.Equal(t, []byte("Hello"), vm.GetSlotBytes(0, len([]byte("Hello"))))
+	assert.Equal(t, []byte("Hello"), vm.GetSlotBytes(0, 0))
+	assert.Equal(t, []byte("He"), vm.GetSlotBytes(0, 2))
 
 	vm.SetSlotDouble(0, 1.337)
 	assert.Equal(t, 1.337, vm.GetSlotDouble(0))
@@ -130,3 +195,38 @@ func TestForeign(t *testing.T) {
 
 	assert.Equal(t, "What are you doing? Damien\n", out)
 }
+
+func TestBuiltinModules(t *testing.T) {
+	var out bytes.Buffer
+
+	fMapGuard.Lock()
+	registered := fCounter
+	fMapGuard.Unlock()
+
+	config := NewConfiguration()
+	config.Stdout = &out
+	config.LoadModuleFunc = func(vm *VM, name string) string {
+		switch name {
+		case "json":
+			return `class JSON { static parse(text) { "from the loader" } }`
+		case "shapes":
+			return `foreign class Shape {}`
+		}
+		return ""
+	}
+	vm := NewVM(config)
+	defer vm.FreeVM()
+
+	// Built-in modules are registered once imported.
+	fMapGuard.Lock()
+	assert.Equal(t, registered, fCounter)
+	fMapGuard.Unlock()
+
+	assert.NoError(t, vm.Interpret(DefaultModule, `
+		import "json" for JSON
+		import "log" for Log
+		import "shapes" for Shape
+		System.print(JSON.parse("{}"))
+	`))
+	assert.Equal(t, "from the loader\n", out.String())
+}